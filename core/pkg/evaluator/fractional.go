@@ -48,26 +48,37 @@ func parseFractionalEvaluationData(values, data any) (string, []fractionalEvalua
 		return "", nil, errors.New("data isn't of type map[string]any")
 	}
 
+	valueToDistribute, valuesArray, err := bucketingValue(valuesArray, dataMap)
+	if err != nil {
+		return "", nil, err
+	}
+
+	feDistributions, err := parseFractionalEvaluationDistributions(valuesArray)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return valueToDistribute, feDistributions, nil
+}
+
+// bucketingValue returns the value to distribute of an operation, the flag key followed by the first argument if it
+// is a string or by the targetingKey otherwise, along with the remaining arguments
+func bucketingValue(values []any, data map[string]any) (string, []any, error) {
 	// Ignore the error as we can't really do anything if the properties are
 	// somehow missing.
-	properties, _ := getFlagdProperties(dataMap)
+	properties, _ := getFlagdProperties(data)
 
-	bucketBy, ok := valuesArray[0].(string)
+	bucketBy, ok := values[0].(string)
 	if ok {
-		valuesArray = valuesArray[1:]
+		values = values[1:]
 	} else {
-		bucketBy, ok = dataMap[targetingKeyKey].(string)
+		bucketBy, ok = data[targetingKeyKey].(string)
 		if !ok {
 			return "", nil, errors.New("bucketing value not supplied and no targetingKey in context")
 		}
 	}
 
-	feDistributions, err := parseFractionalEvaluationDistributions(valuesArray)
-	if err != nil {
-		return "", nil, err
-	}
-
-	return fmt.Sprintf("%s%s", properties.FlagKey, bucketBy), feDistributions, nil
+	return fmt.Sprintf("%s%s", properties.FlagKey, bucketBy), values, nil
 }
 
 func parseFractionalEvaluationDistributions(values []any) ([]fractionalEvaluationDistribution, error) {
@@ -110,9 +121,7 @@ func parseFractionalEvaluationDistributions(values []any) ([]fractionalEvaluatio
 
 // distributeValue calculate hash for given hash key and find the bucket distributions belongs to
 func distributeValue(value string, feDistribution []fractionalEvaluationDistribution) string {
-	hashValue := int32(murmur3.StringSum32(value))
-	hashRatio := math.Abs(float64(hashValue)) / math.MaxInt32
-	bucket := int(hashRatio * 100) // in range [0, 100]
+	bucket := fractionalBucket(value)
 
 	rangeEnd := 0
 	for _, dist := range feDistribution {
@@ -124,3 +133,10 @@ func distributeValue(value string, feDistribution []fractionalEvaluationDistribu
 
 	return ""
 }
+
+// fractionalBucket calculates the hash bucket of the given hash key
+func fractionalBucket(value string) int {
+	hashValue := int32(murmur3.StringSum32(value))
+	hashRatio := math.Abs(float64(hashValue)) / math.MaxInt32
+	return int(hashRatio * 100) // in range [0, 100]
+}
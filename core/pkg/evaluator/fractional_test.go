@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"context"
 	"testing"
 
 	"github.com/open-feature/flagd/core/pkg/logger"
@@ -333,7 +334,9 @@ func TestFractionalEvaluation(t *testing.T) {
 			)
 			je.store.Flags = tt.flags.Flags
 
-			value, variant, reason, _, err := resolve[string](reqID, tt.flagKey, tt.context, je.evaluateVariant)
+			value, variant, reason, _, err := resolve[string](
+				context.Background(), reqID, tt.flagKey, tt.context, je.evaluateVariant,
+			)
 
 			if value != tt.expectedValue {
 				t.Errorf("expected value '%s', got '%s'", tt.expectedValue, value)
@@ -466,7 +469,9 @@ func BenchmarkFractionalEvaluation(b *testing.B) {
 				),
 			)
 			for i := 0; i < b.N; i++ {
-				value, variant, reason, _, err := resolve[string](reqID, tt.flagKey, tt.context, je.evaluateVariant)
+				value, variant, reason, _, err := resolve[string](
+					context.Background(), reqID, tt.flagKey, tt.context, je.evaluateVariant,
+				)
 
 				if value != tt.expectedValue {
 					b.Errorf("expected value '%s', got '%s'", tt.expectedValue, value)
@@ -32,6 +32,7 @@ func NewAnyValue(
 /*
 IEvaluator implementations store the state of the flags,
 do parsing and validation of the flag state and evaluate flags in response to handlers.
+Single flag evaluations performed with a context returned by WithTrace record how the targeting rule was evaluated.
 */
 type IEvaluator interface {
 	GetState() (string, error)
@@ -70,4 +71,9 @@ type IEvaluator interface {
 		ctx context.Context,
 		reqID string,
 		context map[string]any) (values []AnyValue)
+	ResolveAsAnyValue(
+		ctx context.Context,
+		reqID string,
+		flagKey string,
+		context map[string]any) (value AnyValue)
 }
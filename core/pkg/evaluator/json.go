@@ -1,14 +1,15 @@
 package evaluator
 
 import (
-	"bytes"
 	"context"
+	_ "embed"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/diegoholiveira/jsonlogic/v3"
@@ -26,10 +27,22 @@ import (
 	"golang.org/x/exp/maps"
 )
 
+// targetingSchema is the targeting schema of flagd-schemas along with the operations of flagd not part of it yet, such
+// as the progressive operation. It is published as docs/schema/v0/targeting.json.
+//
+//go:embed schema/targeting.json
+var targetingSchema string
+
 const (
 	SelectorMetadataKey = "scope"
+	// RevisionMetadataKey holds the revision of the flag configuration the evaluation is based on
+	RevisionMetadataKey = "revision"
+	// OverrideMetadataKey is set for flags overridden at runtime through the management port
+	OverrideMetadataKey = "override"
 
-	flagdPropertiesKey = "$flagd"
+	flagdPropertiesKey   = "$flagd"
+	flagKeyPropertyKey   = "flagKey"
+	timestampPropertyKey = "timestamp"
 
 	// targetingKeyKey is used to extract the targetingKey to bucket on in fractional
 	// evaluation if the user did not supply the optional bucketing property.
@@ -47,13 +60,17 @@ func init() {
 	regBrace = regexp.MustCompile("^[^{]*{|}[^}]*$")
 }
 
-type variantEvaluator func(string, string, map[string]any) (
+type variantEvaluator func(context.Context, string, string, map[string]any) (
 	variant string, variants map[string]interface{}, reason string, metadata map[string]interface{}, error error)
 
 type JSON struct {
 	store          *store.Flags
 	Logger         *logger.Logger
 	jsonEvalTracer trace.Tracer
+
+	// schema is compiled once per evaluator and can be swapped through RegisterSchema
+	schema           atomic.Pointer[gojsonschema.Schema]
+	strictValidation bool
 }
 
 type constraints interface {
@@ -72,6 +89,15 @@ func WithEvaluator(name string, evalFunc func(interface{}, interface{}) interfac
 	}
 }
 
+// WithStrictValidation makes the evaluator reject flag configurations which do not conform to the flagd schema,
+// instead of only logging a warning. The same applies to targeting rules referencing unknown evaluators or variants
+// which are not defined by the flag, and to fractional weights which do not sum up to 100.
+func WithStrictValidation() JSONEvaluatorOption {
+	return func(je *JSON) {
+		je.strictValidation = true
+	}
+}
+
 func NewJSON(logger *logger.Logger, s *store.Flags, opts ...JSONEvaluatorOption) *JSON {
 	ev := JSON{
 		Logger: logger.WithFields(
@@ -82,6 +108,10 @@ func NewJSON(logger *logger.Logger, s *store.Flags, opts ...JSONEvaluatorOption)
 		jsonEvalTracer: otel.Tracer("jsonEvaluator"),
 	}
 
+	if err := ev.RegisterSchema(schema.FlagSchema, targetingSchema); err != nil {
+		ev.Logger.Warn(err.Error())
+	}
+
 	for _, o := range opts {
 		o(&ev)
 	}
@@ -89,6 +119,18 @@ func NewJSON(logger *logger.Logger, s *store.Flags, opts ...JSONEvaluatorOption)
 	return &ev
 }
 
+// RegisterSchema compiles the given flag definition and targeting schemas and replaces the schema used to validate
+// flag configurations. This allows to switch to a newer schema version without recreating the evaluator.
+func (je *JSON) RegisterSchema(flagSchema string, targetingSchema string) error {
+	compiledSchema, err := compileSchema(flagSchema, targetingSchema)
+	if err != nil {
+		return err
+	}
+
+	je.schema.Store(compiledSchema)
+	return nil
+}
+
 func (je *JSON) GetState() (string, error) {
 	s, err := je.store.String()
 	if err != nil {
@@ -117,18 +159,23 @@ func (je *JSON) SetState(payload sync.DataSync) (map[string]interface{}, bool, e
 	var events map[string]interface{}
 	var reSync bool
 
+	// flags are written to the namespace of their source, where the priority of sources applies
+	flags := je.store.Namespace(je.store.NamespaceOf(payload.Source))
 	switch payload.Type {
 	case sync.ALL:
-		events, reSync = je.store.Merge(je.Logger, payload.Source, newFlags.Flags)
+		events, reSync = flags.Merge(je.Logger, payload.Source, newFlags.Flags)
 	case sync.ADD:
-		events = je.store.Add(je.Logger, payload.Source, newFlags.Flags)
+		events = flags.Add(je.Logger, payload.Source, newFlags.Flags)
 	case sync.UPDATE:
-		events = je.store.Update(je.Logger, payload.Source, newFlags.Flags)
+		events = flags.Update(je.Logger, payload.Source, newFlags.Flags)
 	case sync.DELETE:
-		events = je.store.DeleteFlags(je.Logger, payload.Source, newFlags.Flags)
+		events = flags.DeleteFlags(je.Logger, payload.Source, newFlags.Flags)
 	default:
 		return nil, false, fmt.Errorf("unsupported sync type: %d", payload.Type)
 	}
+	if payload.Type == sync.ALL || payload.Metadata != nil {
+		je.store.SetSyncMetadata(payload.Source, payload.Metadata)
+	}
 
 	// Number of events correlates to the number of flags changed through this sync, record it
 	span.SetAttributes(attribute.Int("feature_flag.change_count", len(events)))
@@ -140,58 +187,96 @@ func (je *JSON) ResolveAllValues(ctx context.Context, reqID string, context map[
 	_, span := je.jsonEvalTracer.Start(ctx, "resolveAll")
 	defer span.End()
 
+	// bulk evaluations are not traced
+	ctx = withoutTrace(ctx)
+
 	values := []AnyValue{}
-	var value interface{}
-	var variant string
-	var reason string
-	var metadata map[string]interface{}
-	var err error
-	allFlags := je.store.GetAll()
+	allFlags := je.getAllFlags(ctx)
+	filter := metadataFilterFromContext(ctx)
+	now := time.Now()
 	for flagKey, flag := range allFlags {
-		if flag.State == Disabled {
+		if flag.State == Disabled || !flag.Schedule.Active(now) {
 			// ignore evaluation of disabled flag
 			continue
 		}
-
-		defaultValue := flag.Variants[flag.DefaultVariant]
-		switch defaultValue.(type) {
-		case bool:
-			value, variant, reason, metadata, err = resolve[bool](
-				reqID,
-				flagKey,
-				context,
-				je.evaluateVariant,
-			)
-		case string:
-			value, variant, reason, metadata, err = resolve[string](
-				reqID,
-				flagKey,
-				context,
-				je.evaluateVariant,
-			)
-		case float64:
-			value, variant, reason, metadata, err = resolve[float64](
-				reqID,
-				flagKey,
-				context,
-				je.evaluateVariant,
-			)
-		case map[string]any:
-			value, variant, reason, metadata, err = resolve[map[string]any](
-				reqID,
-				flagKey,
-				context,
-				je.evaluateVariant,
-			)
+		if !filter.matches(flag) {
+			continue
 		}
-		if err != nil {
-			je.Logger.ErrorWithID(reqID, fmt.Sprintf("bulk evaluation: key: %s returned error: %s", flagKey, err.Error()))
+
+		value := je.resolveAnyValue(ctx, reqID, flagKey, flag, context)
+		if value.Error != nil {
+			je.Logger.ErrorWithID(reqID, fmt.Sprintf("bulk evaluation: key: %s returned error: %s", flagKey, value.Error))
 		}
-		values = append(values, NewAnyValue(value, variant, reason, flagKey, metadata, err))
+		values = append(values, value)
 	}
 	return values
 }
 
+// ResolveAsAnyValue evaluates a single flag without knowing its type upfront, the value is resolved using the type of
+// the flag's default variant
+func (je *JSON) ResolveAsAnyValue(ctx context.Context, reqID string, flagKey string, context map[string]any) AnyValue {
+	_, span := je.jsonEvalTracer.Start(ctx, "resolveAnyValue")
+	defer span.End()
+
+	flag, ok := je.getFlag(ctx, flagKey)
+	if !ok {
+		je.Logger.DebugWithID(reqID, fmt.Sprintf("requested flag could not be found: %s", flagKey))
+		return NewAnyValue(
+			nil, "", model.ErrorReason, flagKey, map[string]interface{}{}, errors.New(model.FlagNotFoundErrorCode),
+		)
+	}
+
+	return je.resolveAnyValue(ctx, reqID, flagKey, flag, context)
+}
+
+func (je *JSON) resolveAnyValue(
+	ctx context.Context, reqID string, flagKey string, flag model.Flag, context map[string]any,
+) AnyValue {
+	var value interface{}
+	var variant string
+	var reason string
+	var metadata map[string]interface{}
+	var err error
+
+	defaultValue := flag.Variants[flag.DefaultVariant]
+	switch defaultValue.(type) {
+	case bool:
+		value, variant, reason, metadata, err = resolve[bool](
+			ctx,
+			reqID,
+			flagKey,
+			context,
+			je.evaluateVariant,
+		)
+	case string:
+		value, variant, reason, metadata, err = resolve[string](
+			ctx,
+			reqID,
+			flagKey,
+			context,
+			je.evaluateVariant,
+		)
+	case float64:
+		value, variant, reason, metadata, err = resolve[float64](
+			ctx,
+			reqID,
+			flagKey,
+			context,
+			je.evaluateVariant,
+		)
+	case map[string]any:
+		value, variant, reason, metadata, err = resolve[map[string]any](
+			ctx,
+			reqID,
+			flagKey,
+			context,
+			je.evaluateVariant,
+		)
+	}
+
+	return NewAnyValue(value, variant, reason, flagKey, metadata, err)
+}
+
 func (je *JSON) ResolveBooleanValue(
 	ctx context.Context, reqID string, flagKey string, context map[string]any) (
 	value bool,
@@ -204,7 +289,7 @@ func (je *JSON) ResolveBooleanValue(
 	defer span.End()
 
 	je.Logger.DebugWithID(reqID, fmt.Sprintf("evaluating boolean flag: %s", flagKey))
-	return resolve[bool](reqID, flagKey, context, je.evaluateVariant)
+	return resolve[bool](ctx, reqID, flagKey, context, je.evaluateVariant)
 }
 
 func (je *JSON) ResolveStringValue(
@@ -219,7 +304,7 @@ func (je *JSON) ResolveStringValue(
 	defer span.End()
 
 	je.Logger.DebugWithID(reqID, fmt.Sprintf("evaluating string flag: %s", flagKey))
-	return resolve[string](reqID, flagKey, context, je.evaluateVariant)
+	return resolve[string](ctx, reqID, flagKey, context, je.evaluateVariant)
 }
 
 func (je *JSON) ResolveFloatValue(
@@ -234,7 +319,7 @@ func (je *JSON) ResolveFloatValue(
 	defer span.End()
 
 	je.Logger.DebugWithID(reqID, fmt.Sprintf("evaluating float flag: %s", flagKey))
-	value, variant, reason, metadata, err = resolve[float64](reqID, flagKey, context, je.evaluateVariant)
+	value, variant, reason, metadata, err = resolve[float64](ctx, reqID, flagKey, context, je.evaluateVariant)
 	return
 }
 
@@ -250,7 +335,7 @@ func (je *JSON) ResolveIntValue(ctx context.Context, reqID string, flagKey strin
 
 	je.Logger.DebugWithID(reqID, fmt.Sprintf("evaluating int flag: %s", flagKey))
 	var val float64
-	val, variant, reason, metadata, err = resolve[float64](reqID, flagKey, context, je.evaluateVariant)
+	val, variant, reason, metadata, err = resolve[float64](ctx, reqID, flagKey, context, je.evaluateVariant)
 	value = int64(val)
 	return
 }
@@ -267,13 +352,13 @@ func (je *JSON) ResolveObjectValue(
 	defer span.End()
 
 	je.Logger.DebugWithID(reqID, fmt.Sprintf("evaluating object flag: %s", flagKey))
-	return resolve[map[string]any](reqID, flagKey, context, je.evaluateVariant)
+	return resolve[map[string]any](ctx, reqID, flagKey, context, je.evaluateVariant)
 }
 
-func resolve[T constraints](reqID string, key string, context map[string]any, variantEval variantEvaluator) (
-	value T, variant string, reason string, metadata map[string]interface{}, err error,
-) {
-	variant, variants, reason, metadata, err := variantEval(reqID, key, context)
+func resolve[T constraints](
+	ctx context.Context, reqID string, key string, context map[string]any, variantEval variantEvaluator,
+) (value T, variant string, reason string, metadata map[string]interface{}, err error) {
+	variant, variants, reason, metadata, err := variantEval(ctx, reqID, key, context)
 	if err != nil {
 		return value, variant, reason, metadata, err
 	}
@@ -289,67 +374,106 @@ func resolve[T constraints](reqID string, key string, context map[string]any, va
 
 // runs the rules (if defined) to determine the variant, otherwise falling through to the default
 // nolint: funlen
-func (je *JSON) evaluateVariant(reqID string, flagKey string, context map[string]any) (
+func (je *JSON) evaluateVariant(ctx context.Context, reqID string, flagKey string, context map[string]any) (
 	variant string, variants map[string]interface{}, reason string, metadata map[string]interface{}, err error,
 ) {
 	metadata = map[string]interface{}{}
 
-	flag, ok := je.store.Get(flagKey)
+	trace := traceFromContext(ctx)
+	if trace != nil {
+		trace.FlagKey = flagKey
+	}
+
+	flag, ok := je.getFlag(ctx, flagKey)
 	if !ok {
 		// flag not found
 		je.Logger.DebugWithID(reqID, fmt.Sprintf("requested flag could not be found: %s", flagKey))
 		return "", map[string]interface{}{}, model.ErrorReason, metadata, errors.New(model.FlagNotFoundErrorCode)
 	}
 
-	// add selector to evaluation metadata
+	// add the metadata of the flag, and of its source, selector and configuration revision to evaluation metadata
+	for key, value := range flag.Metadata {
+		metadata[key] = value
+	}
+	for key, value := range je.store.SyncMetadata(flag.Source) {
+		metadata[key] = value
+	}
 	selector := je.store.SelectorForFlag(flag)
 	if selector != "" {
 		metadata[SelectorMetadataKey] = selector
 	}
+	if flags, ok := je.namespaceStore(ctx); ok {
+		metadata[RevisionMetadataKey] = flags.Revision()
+	}
+	if model.IsOverrideSource(flag.Source) {
+		metadata[OverrideMetadataKey] = true
+	}
+
+	// flags loaded from a snapshot are reported as cached until their source is synced live
+	if je.store.IsSourceCached(flag.Source) {
+		defer func() {
+			if err == nil {
+				reason = model.CachedReason
+			}
+		}()
+	}
 
 	if flag.State == Disabled {
 		je.Logger.DebugWithID(reqID, fmt.Sprintf("requested flag is disabled: %s", flagKey))
 		return "", flag.Variants, model.ErrorReason, metadata, errors.New(model.FlagDisabledErrorCode)
 	}
+	now := time.Now()
+	if !flag.Schedule.Active(now) {
+		je.Logger.DebugWithID(reqID, fmt.Sprintf("requested flag is disabled by its schedule: %s", flagKey))
+		return "", flag.Variants, model.ErrorReason, metadata, errors.New(model.FlagDisabledErrorCode)
+	}
 
-	// get the targeting logic, if any
-	targeting := flag.Targeting
-
-	if targeting != nil && string(targeting) != "{}" {
-		targetingBytes, err := targeting.MarshalJSON()
+	// get the targeting logic, if any. Flags synced through SetState carry their compiled rule tree, others are
+	// compiled on demand
+	targeting := flag.CompiledTargeting
+	if targeting == nil {
+		targeting, err = compileTargeting(flag.Targeting)
 		if err != nil {
 			je.Logger.ErrorWithID(reqID, fmt.Sprintf("Error parsing rules for flag: %s, %s", flagKey, err))
 			return "", flag.Variants, model.ErrorReason, metadata, errors.New(model.ParseErrorCode)
 		}
+	}
 
+	if targeting != nil {
 		context = je.setFlagdProperties(context, flagdProperties{
 			FlagKey:   flagKey,
-			Timestamp: time.Now().Unix(),
+			Timestamp: now.Unix(),
 		})
 
-		b, err := json.Marshal(context)
-		if err != nil {
+		if err := normalizeContext(context); err != nil {
 			je.Logger.ErrorWithID(reqID, fmt.Sprintf("error parsing context for flag: %s, %s, %v", flagKey, err, context))
 
 			return "", flag.Variants, model.ErrorReason, metadata, errors.New(model.ErrorReason)
 		}
 
-		var result bytes.Buffer
-		// evaluate JsonLogic rules to determine the variant
-		err = jsonlogic.Apply(bytes.NewReader(targetingBytes), bytes.NewReader(b), &result)
+		// evaluate JsonLogic rules to determine the variant, recording each operation if the evaluation is traced
+		var result interface{}
+		if trace != nil {
+			result, err = trace.record(targeting, context)
+		} else {
+			result, err = applyTargeting(targeting, context)
+		}
 		if err != nil {
 			je.Logger.ErrorWithID(reqID, fmt.Sprintf("error applying rules: %s", err))
 			return "", flag.Variants, model.ErrorReason, metadata, errors.New(model.ParseErrorCode)
 		}
 
-		// check if string is "null" before we strip quotes, so we can differentiate between JSON null and "null"
-		trimmed := strings.TrimSpace(result.String())
-		if trimmed == "null" {
-			return flag.DefaultVariant, flag.Variants, model.DefaultReason, metadata, nil
+		// a JSON null result falls back to the default variant
+		if result == nil {
+			variant, reason = scheduledVariant(flagKey, flag, context, model.DefaultReason, now)
+			return variant, flag.Variants, reason, metadata, nil
 		}
 
-		// strip whitespace and quotes from the variant
-		variant = strings.ReplaceAll(trimmed, "\"", "")
+		variant, err = targetingResultToVariant(result)
+		if err != nil {
+			je.Logger.ErrorWithID(reqID, fmt.Sprintf("error parsing targeting result for flag: %s, %s", flagKey, err))
+			return "", flag.Variants, model.ErrorReason, metadata, errors.New(model.ParseErrorCode)
+		}
 
 		// if this is a valid variant, return it
 		if _, ok := flag.Variants[variant]; ok {
@@ -359,7 +483,8 @@ func (je *JSON) evaluateVariant(reqID string, flagKey string, context map[string
 			fmt.Sprintf("invalid or missing variant: %s for flagKey: %s, variant is not valid", variant, flagKey))
 		return "", flag.Variants, model.ErrorReason, metadata, errors.New(model.ParseErrorCode)
 	}
-	return flag.DefaultVariant, flag.Variants, model.StaticReason, metadata, nil
+	variant, reason = scheduledVariant(flagKey, flag, context, model.StaticReason, now)
+	return variant, flag.Variants, reason, metadata, nil
 }
 
 func (je *JSON) setFlagdProperties(
@@ -376,7 +501,11 @@ func (je *JSON) setFlagdProperties(
 		je.Logger.Warn("overwriting $flagd properties in the context")
 	}
 
-	newContext[flagdPropertiesKey] = properties
+	// properties are stored in their JSON representation, as this is what JSONLogic operates on
+	newContext[flagdPropertiesKey] = map[string]any{
+		flagKeyPropertyKey:   properties.FlagKey,
+		timestampPropertyKey: float64(properties.Timestamp),
+	}
 
 	return newContext
 }
@@ -387,6 +516,12 @@ func getFlagdProperties(context map[string]any) (flagdProperties, bool) {
 		return flagdProperties{}, false
 	}
 
+	if m, ok := properties.(map[string]any); ok {
+		flagKey, _ := m[flagKeyPropertyKey].(string)
+		timestamp, _ := m[timestampPropertyKey].(float64)
+		return flagdProperties{FlagKey: flagKey, Timestamp: int64(timestamp)}, true
+	}
+
 	b, err := json.Marshal(properties)
 	if err != nil {
 		return flagdProperties{}, false
@@ -400,38 +535,30 @@ func getFlagdProperties(context map[string]any) (flagdProperties, bool) {
 	return p, true
 }
 
-func (je *JSON) loadAndCompileSchema() *gojsonschema.Schema {
+func compileSchema(flagSchema string, targetingSchema string) (*gojsonschema.Schema, error) {
 	schemaLoader := gojsonschema.NewSchemaLoader()
 
 	// compile dependency schema
-	targetingSchemaLoader := gojsonschema.NewStringLoader(schema.TargetingSchema)
+	targetingSchemaLoader := gojsonschema.NewStringLoader(targetingSchema)
 	if err := schemaLoader.AddSchemas(targetingSchemaLoader); err != nil {
-		je.Logger.Warn(fmt.Sprintf("error adding Targeting schema: %s", err))
+		return nil, fmt.Errorf("error adding Targeting schema: %w", err)
 	}
 
 	// compile root schema
-	flagdDefinitionsLoader := gojsonschema.NewStringLoader(schema.FlagSchema)
+	flagdDefinitionsLoader := gojsonschema.NewStringLoader(flagSchema)
 	compiledSchema, err := schemaLoader.Compile(flagdDefinitionsLoader)
 	if err != nil {
-		je.Logger.Warn(fmt.Sprintf("error compiling FlagdDefinitions schema: %s", err))
+		return nil, fmt.Errorf("error compiling FlagdDefinitions schema: %w", err)
 	}
 
-	return compiledSchema
+	return compiledSchema, nil
 }
 
 // configToFlags convert string configurations to flags and store them to pointer newFlags
 func (je *JSON) configToFlags(config string, newFlags *Flags) error {
-	compiledSchema := je.loadAndCompileSchema()
-
-	flagStringLoader := gojsonschema.NewStringLoader(config)
-
-	result, err := compiledSchema.Validate(flagStringLoader)
+	err := je.validateSchema(config)
 	if err != nil {
-		je.Logger.Warn(fmt.Sprintf("failed to execute JSON schema validation: %s", err))
-	} else if !result.Valid() {
-		je.Logger.Warn(fmt.Sprintf(
-			"flag definition does not conform to the schema; validation errors: %s", buildErrorString(result.Errors()),
-		))
+		return err
 	}
 
 	transposedConfig, err := je.transposeEvaluators(config)
@@ -444,17 +571,65 @@ func (je *JSON) configToFlags(config string, newFlags *Flags) error {
 		return fmt.Errorf("unmarshalling provided configurations: %w", err)
 	}
 
-	return validateDefaultVariants(newFlags)
+	err = validateDefaultVariants(newFlags)
+	if err != nil {
+		return err
+	}
+
+	err = validateSchedules(newFlags)
+	if err != nil {
+		return err
+	}
+
+	err = validateMetadata(newFlags)
+	if err != nil {
+		if je.strictValidation {
+			return err
+		}
+		je.Logger.Warn(fmt.Sprintf("flag definition contains invalid metadata: %s", err))
+	}
+	mergeMetadata(newFlags)
+
+	err = compileFlagsTargeting(newFlags)
+	if err != nil {
+		return err
+	}
+
+	err = validateTargeting(newFlags)
+	if err != nil {
+		if je.strictValidation {
+			return err
+		}
+		je.Logger.Warn(fmt.Sprintf("flag definition contains invalid targeting: %s", err))
+	}
+
+	return nil
 }
 
-// validateDefaultVariants returns an error if any of the default variants aren't valid
-func validateDefaultVariants(flags *Flags) error {
-	for name, flag := range flags.Flags {
-		if _, ok := flag.Variants[flag.DefaultVariant]; !ok {
+// validateSchema validates the configuration against the registered schema. Violations are only logged, unless strict
+// validation is enabled.
+func (je *JSON) validateSchema(config string) error {
+	compiledSchema := je.schema.Load()
+	if compiledSchema == nil {
+		je.Logger.Warn("no JSON schema registered, skipping flag definition validation")
+		return nil
+	}
+
+	result, err := compiledSchema.Validate(gojsonschema.NewStringLoader(config))
+	if err != nil {
+		if je.strictValidation {
+			return fmt.Errorf("failed to execute JSON schema validation: %w", err)
+		}
+		je.Logger.Warn(fmt.Sprintf("failed to execute JSON schema validation: %s", err))
+	} else if !result.Valid() {
+		if je.strictValidation {
 			return fmt.Errorf(
-				"default variant: '%s' isn't a valid variant of flag: '%s'", flag.DefaultVariant, name,
+				"flag definition does not conform to the schema; validation errors: %s", buildErrorString(result.Errors()),
 			)
 		}
+		je.Logger.Warn(fmt.Sprintf(
+			"flag definition does not conform to the schema; validation errors: %s", buildErrorString(result.Errors()),
+		))
 	}
 
 	return nil
@@ -12,4 +12,6 @@ type Evaluators struct {
 
 type Flags struct {
 	Flags map[string]model.Flag `json:"flags"`
+	// Metadata of the flag set, merged into the metadata of each of its flags
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -2,6 +2,7 @@
 package evaluator_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,12 +11,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/diegoholiveira/jsonlogic/v3"
 	"github.com/open-feature/flagd/core/pkg/evaluator"
 	"github.com/open-feature/flagd/core/pkg/logger"
 	"github.com/open-feature/flagd/core/pkg/model"
 	"github.com/open-feature/flagd/core/pkg/store"
 	"github.com/open-feature/flagd/core/pkg/sync"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 const InvalidFlags = `{
@@ -317,6 +321,190 @@ func TestSetState_Valid_NoError(t *testing.T) {
 	}
 }
 
+func TestSetState_StrictValidation(t *testing.T) {
+	// "ON" is not a valid state according to the flagd schema
+	const nonConformingFlags = `{
+  "flags": {
+    "validFlag": {
+      "state": "ON",
+      "variants": {
+        "on": true,
+        "off": false
+      },
+      "defaultVariant": "on"
+    }
+  }
+}`
+
+	t.Run("schema violations are only logged by default", func(t *testing.T) {
+		je := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags())
+		_, _, err := je.SetState(sync.DataSync{FlagData: nonConformingFlags})
+		assert.NoError(t, err)
+	})
+
+	t.Run("schema violations are rejected in strict mode", func(t *testing.T) {
+		je := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags(), evaluator.WithStrictValidation())
+		_, _, err := je.SetState(sync.DataSync{FlagData: nonConformingFlags})
+		assert.ErrorContains(t, err, "does not conform to the schema")
+
+		_, _, err = je.SetState(sync.DataSync{FlagData: ValidFlags})
+		assert.NoError(t, err)
+	})
+
+	t.Run("progressive operations conform to the schema", func(t *testing.T) {
+		je := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags(), evaluator.WithStrictValidation())
+		_, _, err := je.SetState(sync.DataSync{FlagData: `{
+  "flags": {
+    "progressiveFlag": {
+      "state": "ENABLED",
+      "variants": {
+        "on": true,
+        "off": false
+      },
+      "defaultVariant": "off",
+      "targeting": {
+        "progressive": [
+          { "var": "email" },
+          [ "on", "off" ],
+          [ "2024-06-01T00:00:00Z", 1717804800 ],
+          [ 0, 100 ]
+        ]
+      }
+    },
+    "shorthandFlag": {
+      "state": "ENABLED",
+      "variants": {
+        "on": true,
+        "off": false
+      },
+      "defaultVariant": "off",
+      "targeting": {
+        "progressive": [ [ "on", "off" ], [ 1717200000, 1717804800 ], [ 0, 100 ] ]
+      }
+    }
+  }
+}`})
+		assert.NoError(t, err)
+
+		_, _, err = je.SetState(sync.DataSync{FlagData: `{
+  "flags": {
+    "progressiveFlag": {
+      "state": "ENABLED",
+      "variants": {
+        "on": true,
+        "off": false
+      },
+      "defaultVariant": "off",
+      "targeting": {
+        "progressive": [ [ "on", "off" ], [ 1717200000, 1717804800 ], [ 0, 200 ] ]
+      }
+    }
+  }
+}`})
+		assert.ErrorContains(t, err, "does not conform to the schema")
+	})
+
+	tests := map[string]struct {
+		targeting string
+		wantErr   string
+	}{
+		"unknown evaluator reference": {
+			targeting: `{ "if": [ { "$ref": "unknown" }, "on", "off" ] }`,
+			wantErr:   "reference to unknown evaluator 'unknown'",
+		},
+		"missing variant": {
+			targeting: `{ "if": [ { "==": [ { "var": "email" }, "a@b.c" ] }, "on", "missing" ] }`,
+			wantErr:   "variant 'missing' isn't a valid variant of the flag",
+		},
+		"missing fractional variant": {
+			targeting: `{ "fractional": [ [ "on", 50 ], [ "missing", 50 ] ] }`,
+			wantErr:   "variant 'missing' isn't a valid variant of the flag",
+		},
+		"missing variant of shared evaluator": {
+			targeting: `{ "if": [ { "var": "email" }, { "$ref": "isMissing" }, "off" ] }`,
+			wantErr:   "variant 'missing' isn't a valid variant of the flag",
+		},
+		"fractional weights not summing to 100": {
+			targeting: `{ "fractional": [ { "var": "email" }, [ "on", 50 ], [ "off", 30 ] ] }`,
+			wantErr:   "fractional percentages must sum to 100, got: 80",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			flags := fmt.Sprintf(`{
+  "flags": {
+    "validFlag": {
+      "state": "ENABLED",
+      "variants": {
+        "on": true,
+        "off": false
+      },
+      "defaultVariant": "on",
+      "targeting": %s
+    }
+  },
+  "$evaluators": {
+    "isMissing": { "fractional": [ [ "on", 50 ], [ "missing", 50 ] ] }
+  }
+}`, tt.targeting)
+
+			// targeting issues are only logged by default
+			observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+			je := evaluator.NewJSON(logger.NewLogger(zap.New(observedZapCore), false), store.NewFlags())
+			_, _, err := je.SetState(sync.DataSync{FlagData: flags, Source: "source"})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, observedLogs.FilterMessageSnippet(tt.wantErr).Len())
+
+			je = evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags(), evaluator.WithStrictValidation())
+			_, _, err = je.SetState(sync.DataSync{FlagData: ValidFlags, Source: "source"})
+			assert.NoError(t, err)
+
+			_, _, err = je.SetState(sync.DataSync{FlagData: flags, Source: "source"})
+			assert.ErrorContains(t, err, tt.wantErr)
+
+			// the previous state of the source is kept
+			_, _, reason, _, err := je.ResolveBooleanValue(context.TODO(), "default", "validFlag", nil)
+			assert.NoError(t, err)
+			assert.Equal(t, model.StaticReason, reason)
+		})
+	}
+}
+
+func TestRegisterSchema(t *testing.T) {
+	je := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags(), evaluator.WithStrictValidation())
+
+	_, _, err := je.SetState(sync.DataSync{FlagData: ValidFlags})
+	assert.NoError(t, err)
+
+	// register a schema which does not allow any flags to be defined
+	err = je.RegisterSchema(`{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "flags": {
+      "type": "object",
+      "maxProperties": 0
+    }
+  }
+}`, `{
+  "$id": "https://flagd.dev/schema/v1/targeting.json",
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object"
+}`)
+	assert.NoError(t, err)
+
+	_, _, err = je.SetState(sync.DataSync{FlagData: ValidFlags})
+	assert.ErrorContains(t, err, "does not conform to the schema")
+
+	// an invalid schema must not replace the registered one
+	err = je.RegisterSchema(`{ "type": 1 }`, `{}`)
+	assert.Error(t, err)
+
+	_, _, err = je.SetState(sync.DataSync{FlagData: ValidFlags})
+	assert.ErrorContains(t, err, "does not conform to the schema")
+}
+
 func TestResolveAllValues(t *testing.T) {
 	evaluator := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags())
 	_, _, err := evaluator.SetState(sync.DataSync{FlagData: Flags})
@@ -368,6 +556,327 @@ func TestResolveAllValues(t *testing.T) {
 	}
 }
 
+func TestResolveAsAnyValue(t *testing.T) {
+	tests := []struct {
+		flagKey   string
+		context   map[string]interface{}
+		val       interface{}
+		reason    string
+		errorCode string
+	}{
+		{StaticBoolFlag, nil, StaticBoolValue, model.StaticReason, ""},
+		{StaticStringFlag, nil, StaticStringValue, model.StaticReason, ""},
+		{
+			DynamicStringFlag, map[string]interface{}{ColorProp: ColorValue}, DynamicStringValue,
+			model.TargetingMatchReason, "",
+		},
+		{MissingFlag, nil, nil, model.ErrorReason, model.FlagNotFoundErrorCode},
+		{DisabledFlag, nil, false, model.ErrorReason, model.FlagDisabledErrorCode},
+	}
+	const reqID = "default"
+	evaluator := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags())
+	_, _, err := evaluator.SetState(sync.DataSync{FlagData: Flags})
+	if err != nil {
+		t.Fatalf("expected no error")
+	}
+
+	for _, test := range tests {
+		val := evaluator.ResolveAsAnyValue(context.TODO(), reqID, test.flagKey, test.context)
+
+		assert.Equal(t, test.flagKey, val.FlagKey)
+		assert.Equal(t, test.val, val.Value)
+		assert.Equal(t, test.reason, val.Reason)
+		if test.errorCode == "" {
+			assert.NoError(t, val.Error)
+		} else {
+			assert.EqualError(t, val.Error, test.errorCode)
+		}
+	}
+}
+
+func TestEvaluationMetadata(t *testing.T) {
+	const reqID = "default"
+	s := store.NewFlags()
+	s.FlagSources = []string{"A", model.OverrideSource}
+	evaluator := evaluator.NewJSON(logger.NewLogger(nil, false), s)
+	_, _, err := evaluator.SetState(sync.DataSync{FlagData: Flags, Source: "A"})
+	assert.NoError(t, err)
+
+	val := evaluator.ResolveAsAnyValue(context.TODO(), reqID, StaticBoolFlag, nil)
+	assert.Equal(t, uint64(1), val.Metadata["revision"])
+	assert.NotContains(t, val.Metadata, "override")
+
+	_, _, err = evaluator.SetState(sync.DataSync{
+		FlagData: fmt.Sprintf(
+			`{"flags":{"%s":{"state":"ENABLED","variants":{"on":true,"off":false},"defaultVariant":"off"}}}`,
+			StaticBoolFlag,
+		),
+		Source: model.OverrideSource,
+	})
+	assert.NoError(t, err)
+
+	val = evaluator.ResolveAsAnyValue(context.TODO(), reqID, StaticBoolFlag, nil)
+	assert.Equal(t, false, val.Value)
+	assert.Equal(t, uint64(2), val.Metadata["revision"])
+	assert.Equal(t, true, val.Metadata["override"])
+}
+
+func TestSyncMetadata(t *testing.T) {
+	const reqID = "default"
+	s := store.NewFlags()
+	evaluator := evaluator.NewJSON(logger.NewLogger(nil, false), s)
+	_, _, err := evaluator.SetState(sync.DataSync{
+		FlagData: Flags,
+		Source:   "A",
+		Metadata: map[string]interface{}{"commit": "abc", "revision": "ignored"},
+	})
+	assert.NoError(t, err)
+
+	val := evaluator.ResolveAsAnyValue(context.TODO(), reqID, StaticBoolFlag, nil)
+	assert.Equal(t, "abc", val.Metadata["commit"])
+	assert.Equal(t, uint64(1), val.Metadata["revision"], "sync metadata does not replace evaluation metadata")
+	assert.Equal(t, map[string]interface{}{"commit": "abc", "revision": "ignored"}, s.SyncMetadata("A"))
+
+	// partial updates keep the metadata of the source, full ones replace it
+	_, _, err = evaluator.SetState(sync.DataSync{FlagData: `{"flags":{}}`, Source: "A", Type: sync.UPDATE})
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", s.SyncMetadata("A")["commit"])
+	_, _, err = evaluator.SetState(sync.DataSync{FlagData: Flags, Source: "A"})
+	assert.NoError(t, err)
+	val = evaluator.ResolveAsAnyValue(context.TODO(), reqID, StaticBoolFlag, nil)
+	assert.NotContains(t, val.Metadata, "commit")
+}
+
+func TestSelector(t *testing.T) {
+	const reqID = "default"
+	flag := func(key string, value string) string {
+		return fmt.Sprintf(`{"flags":{"%s":{"state":"ENABLED","variants":{"v":"%s"},"defaultVariant":"v"}}}`, key, value)
+	}
+	s := store.NewFlags()
+	s.FlagSources = []string{"A", "B", model.OverrideSource}
+	s.SourceMetadata = map[string]store.SourceDetails{"A": {Source: "A", Selector: "app=a"}}
+	je := evaluator.NewJSON(logger.NewLogger(nil, false), s)
+	for source, flagData := range map[string]string{"A": flag("color", "red"), "B": flag("color", "blue")} {
+		_, _, err := je.SetState(sync.DataSync{FlagData: flagData, Source: source})
+		assert.NoError(t, err)
+	}
+
+	val := je.ResolveAsAnyValue(context.TODO(), reqID, "color", nil)
+	assert.Equal(t, "blue", val.Value)
+	val = je.ResolveAsAnyValue(evaluator.WithSelector(context.TODO(), "app=a"), reqID, "color", nil)
+	assert.Equal(t, "red", val.Value)
+	assert.Equal(t, "app=a", val.Metadata["scope"])
+	value, _, _, _, err := je.ResolveStringValue(evaluator.WithSelector(context.TODO(), "B"), reqID, "color", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "blue", value)
+	_, _, _, _, err = je.ResolveStringValue(evaluator.WithSelector(context.TODO(), "C"), reqID, "color", nil)
+	assert.EqualError(t, err, model.FlagNotFoundErrorCode)
+
+	values := je.ResolveAllValues(evaluator.WithSelector(context.TODO(), "app=a"), reqID, nil)
+	assert.Len(t, values, 1)
+	assert.Equal(t, "red", values[0].Value)
+	assert.Empty(t, je.ResolveAllValues(evaluator.WithSelector(context.TODO(), "C"), reqID, nil))
+
+	// overrides apply whatever the selector
+	_, _, err = je.SetState(sync.DataSync{FlagData: flag("color", "green"), Source: model.OverrideSource})
+	assert.NoError(t, err)
+	val = je.ResolveAsAnyValue(evaluator.WithSelector(context.TODO(), "app=a"), reqID, "color", nil)
+	assert.Equal(t, "green", val.Value)
+	values = je.ResolveAllValues(evaluator.WithSelector(context.TODO(), "app=a"), reqID, nil)
+	assert.Equal(t, "green", values[0].Value)
+}
+
+func TestNamespace(t *testing.T) {
+	const reqID = "default"
+	flag := func(value string) string {
+		return fmt.Sprintf(`{"flags":{"checkout":{"state":"ENABLED","variants":{"v":"%s"},"defaultVariant":"v"}}}`, value)
+	}
+	s := store.NewFlags()
+	s.FlagSources = []string{"A", "B", "C"}
+	s.SourceMetadata = map[string]store.SourceDetails{
+		"B": {Source: "B", Namespace: "team"},
+		"C": {Source: "C", Namespace: "team"},
+	}
+	s.Namespace("team").FlagSources = []string{"B", "C"}
+	je := evaluator.NewJSON(logger.NewLogger(nil, false), s)
+	for source, flagData := range map[string]string{"A": flag("a"), "B": flag("b"), "C": flag("c")} {
+		_, _, err := je.SetState(sync.DataSync{FlagData: flagData, Source: source})
+		assert.NoError(t, err)
+	}
+
+	// each namespace sees its own flags, the priority of sources applying within the namespace
+	val := je.ResolveAsAnyValue(context.TODO(), reqID, "checkout", nil)
+	assert.Equal(t, "a", val.Value)
+	ctx := evaluator.WithNamespace(context.TODO(), "team")
+	assert.Equal(t, "team", evaluator.NamespaceFromContext(ctx))
+	value, _, _, _, err := je.ResolveStringValue(ctx, reqID, "checkout", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "c", value)
+	values := je.ResolveAllValues(ctx, reqID, nil)
+	assert.Len(t, values, 1)
+	assert.Equal(t, "c", values[0].Value)
+	assert.Equal(t, "b", je.ResolveAsAnyValue(evaluator.WithSelector(ctx, "B"), reqID, "checkout", nil).Value)
+
+	// unknown namespaces hold no flag
+	ctx = evaluator.WithNamespace(context.TODO(), "other")
+	_, _, _, _, err = je.ResolveStringValue(ctx, reqID, "checkout", nil)
+	assert.EqualError(t, err, model.FlagNotFoundErrorCode)
+	assert.Empty(t, je.ResolveAllValues(ctx, reqID, nil))
+}
+
+func TestMetadata(t *testing.T) {
+	const reqID = "default"
+	je := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags())
+	_, _, err := je.SetState(sync.DataSync{FlagData: `{
+		"metadata": {"owner": "platform", "tier": 1},
+		"flags": {
+			"checkout": {
+				"state": "ENABLED", "variants": {"on": true}, "defaultVariant": "on",
+				"metadata": {"owner": "checkout", "expires": "2025-01-01"}
+			},
+			"search": {"state": "ENABLED", "variants": {"on": true}, "defaultVariant": "on"}
+		}
+	}`, Source: "A"})
+	assert.NoError(t, err)
+
+	// metadata of the flag set is merged into the metadata of each flag, entries of the flag taking precedence
+	val := je.ResolveAsAnyValue(context.TODO(), reqID, "checkout", nil)
+	assert.Equal(t, "checkout", val.Metadata["owner"])
+	assert.Equal(t, "2025-01-01", val.Metadata["expires"])
+	assert.Equal(t, float64(1), val.Metadata["tier"])
+	_, _, _, metadata, err := je.ResolveBooleanValue(context.TODO(), reqID, "search", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "platform", metadata["owner"])
+	assert.Equal(t, uint64(1), metadata[evaluator.RevisionMetadataKey])
+
+	tests := map[string]struct {
+		filter string
+		want   []string
+	}{
+		"no filter":      {want: []string{"checkout", "search"}},
+		"value":          {filter: "owner=checkout", want: []string{"checkout"}},
+		"number":         {filter: "owner=platform, tier=1", want: []string{"search"}},
+		"key":            {filter: "expires", want: []string{"checkout"}},
+		"no match":       {filter: "owner=checkout,tier=2", want: []string{}},
+		"unknown key":    {filter: "team", want: []string{}},
+		"empty segments": {filter: ",owner=platform,", want: []string{"search"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			keys := []string{}
+			for _, value := range je.ResolveAllValues(evaluator.WithMetadataFilter(context.TODO(), tt.filter), reqID, nil) {
+				keys = append(keys, value.FlagKey)
+			}
+			assert.ElementsMatch(t, tt.want, keys)
+		})
+	}
+
+	// metadata must hold strings, numbers or booleans
+	invalid := `{"flags":{"a":{"state":"ENABLED","variants":{"on":true},"defaultVariant":"on","metadata":{"tags":["x"]}}}}`
+	_, _, err = je.SetState(sync.DataSync{FlagData: invalid, Source: "A"})
+	assert.NoError(t, err)
+	strict := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags(), evaluator.WithStrictValidation())
+	_, _, err = strict.SetState(sync.DataSync{FlagData: invalid, Source: "A"})
+	assert.ErrorContains(t, err, "metadata 'tags' must be a string, a number or a boolean")
+}
+
+func TestSchedule(t *testing.T) {
+	const reqID = "default"
+	at := func(d time.Duration) string {
+		return time.Now().Add(d).UTC().Format(time.RFC3339)
+	}
+	flag := func(schedule string) string {
+		return fmt.Sprintf(`{"state":"ENABLED","variants":{"on":true,"off":false},"defaultVariant":"off","schedule":%s}`,
+			schedule)
+	}
+	je := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags())
+	_, _, err := je.SetState(sync.DataSync{FlagData: fmt.Sprintf(`{"flags":{
+		"pending": %s, "active": %s, "expired": %s, "ramped": %s, "ramping": %s, "unramped": %s
+	}}`,
+		flag(fmt.Sprintf(`{"activate":"%s"}`, at(time.Hour))),
+		flag(fmt.Sprintf(`{"activate":"%s","deactivate":"%s"}`, at(-time.Hour), at(time.Hour))),
+		flag(fmt.Sprintf(`{"deactivate":"%s"}`, at(-time.Hour))),
+		flag(fmt.Sprintf(`{"ramp":{"variant":"on","start":"%s","end":"%s"}}`, at(-2*time.Hour), at(-time.Hour))),
+		flag(fmt.Sprintf(`{"ramp":{"variant":"on","start":"%s","end":"%s"}}`, at(-time.Hour), at(time.Hour))),
+		flag(fmt.Sprintf(`{"ramp":{"variant":"on","start":"%s","end":"%s"}}`, at(time.Hour), at(2*time.Hour))),
+	), Source: "A"})
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		variant string
+		reason  string
+		err     string
+	}{
+		"pending":  {reason: model.ErrorReason, err: model.FlagDisabledErrorCode},
+		"active":   {variant: "off", reason: model.StaticReason},
+		"expired":  {reason: model.ErrorReason, err: model.FlagDisabledErrorCode},
+		"ramped":   {variant: "on", reason: model.StaticReason},
+		"unramped": {variant: "off", reason: model.StaticReason},
+	}
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			_, variant, reason, _, err := je.ResolveBooleanValue(context.TODO(), reqID, key, nil)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.variant, variant)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	// while the ramp is in progress, targeting keys are split between the variant of the ramp and the default variant
+	variants := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		_, variant, reason, _, err := je.ResolveBooleanValue(
+			context.TODO(), reqID, "ramping", map[string]any{"targetingKey": fmt.Sprintf("user-%d", i)})
+		assert.NoError(t, err)
+		assert.Equal(t, model.SplitReason, reason)
+		variants[variant]++
+	}
+	assert.InDelta(t, 500, variants["on"], 100)
+	_, variant, reason, _, err := je.ResolveBooleanValue(context.TODO(), reqID, "ramping", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "off", variant, "evaluations without targeting key are served the default variant")
+	assert.Equal(t, model.StaticReason, reason)
+
+	// flags disabled by their schedule are skipped by bulk evaluations
+	keys := []string{}
+	for _, value := range je.ResolveAllValues(context.TODO(), reqID, nil) {
+		keys = append(keys, value.FlagKey)
+	}
+	assert.ElementsMatch(t, []string{"active", "ramped", "ramping", "unramped"}, keys)
+
+	// schedules can't deactivate flags before activating them, nor ramp up to an unknown variant
+	issues := je.Validate(fmt.Sprintf(`{"flags":{"a":%s}}`, flag(fmt.Sprintf(
+		`{"activate":"%s","deactivate":"%s","ramp":{"variant":"missing","start":"%s","end":"%s"}}`,
+		at(time.Hour), at(-time.Hour), at(time.Hour), at(-time.Hour)))))
+	assert.Equal(t, []evaluator.ValidationIssue{
+		{Path: "flags.a.schedule", Message: "schedule of flag 'a' deactivates it before activating it"},
+		{Path: "flags.a.schedule.ramp.variant", Message: "ramp variant: 'missing' isn't a valid variant of flag: 'a'"},
+		{Path: "flags.a.schedule.ramp", Message: "ramp of flag 'a' must start before it ends"},
+	}, issues)
+}
+
+func TestCachedSourceReason(t *testing.T) {
+	const reqID = "default"
+	s := store.NewFlags()
+	evaluator := evaluator.NewJSON(logger.NewLogger(nil, false), s)
+	_, _, err := evaluator.SetState(sync.DataSync{FlagData: Flags, Source: "A"})
+	assert.NoError(t, err)
+
+	s.SetSourceCached("A", true)
+	val := evaluator.ResolveAsAnyValue(context.TODO(), reqID, StaticBoolFlag, nil)
+	assert.Equal(t, model.CachedReason, val.Reason)
+	val = evaluator.ResolveAsAnyValue(context.TODO(), reqID, DisabledFlag, nil)
+	assert.Equal(t, model.ErrorReason, val.Reason, "errors are not reported as cached")
+
+	s.SetSourceCached("A", false)
+	val = evaluator.ResolveAsAnyValue(context.TODO(), reqID, StaticBoolFlag, nil)
+	assert.Equal(t, model.StaticReason, val.Reason)
+}
+
 func TestResolveBooleanValue(t *testing.T) {
 	tests := []struct {
 		flagKey   string
@@ -443,6 +952,149 @@ func BenchmarkResolveBooleanValue(b *testing.B) {
 	}
 }
 
+const TargetingBenchmarkFlags = `{
+  "flags": {
+    "fractionalFlag": {
+      "state": "ENABLED",
+      "variants": {
+        "on": true,
+        "off": false
+      },
+      "defaultVariant": "off",
+      "targeting": {
+        "fractional": [
+          { "var": "email" },
+          [ "on", 50 ],
+          [ "off", 50 ]
+        ]
+      }
+    },
+    "semVerFlag": {
+      "state": "ENABLED",
+      "variants": {
+        "on": true,
+        "off": false
+      },
+      "defaultVariant": "off",
+      "targeting": {
+        "if": [ { "sem_ver": [ { "var": "version" }, ">=", "1.0.0" ] }, "on", "off" ]
+      }
+    },
+    "nestedIfFlag": {
+      "state": "ENABLED",
+      "variants": {
+        "on": true,
+        "off": false
+      },
+      "defaultVariant": "off",
+      "targeting": {
+        "if": [
+          { "in": [ "@faas.com", { "var": "email" } ] },
+          {
+            "if": [
+              { "==": [ { "var": "tier" }, "premium" ] },
+              "on",
+              { "if": [ { ">": [ { "var": "age" }, 18 ] }, "on", "off" ] }
+            ]
+          },
+          "off"
+        ]
+      }
+    }
+  }
+}`
+
+// BenchmarkResolveBooleanValueTargeting compares evaluations of targeting rules compiled once on sync against rules
+// which are parsed on every evaluation, as it is the case for flags not set through SetState, and against the former
+// evaluation path marshalling the rule and the context for JSONLogic to parse them again
+func BenchmarkResolveBooleanValueTargeting(b *testing.B) {
+	tests := []struct {
+		flagKey string
+		context map[string]interface{}
+	}{
+		{"fractionalFlag", map[string]interface{}{"email": "user@faas.com"}},
+		{"semVerFlag", map[string]interface{}{"version": "1.2.3"}},
+		{"nestedIfFlag", map[string]interface{}{"email": "user@faas.com", "tier": "basic", "age": float64(21)}},
+	}
+
+	log := logger.NewLogger(nil, false)
+	newEvaluator := func(s *store.Flags) *evaluator.JSON {
+		return evaluator.NewJSON(log, s,
+			evaluator.WithEvaluator(
+				evaluator.FractionEvaluationName,
+				evaluator.NewFractional(log).Evaluate,
+			),
+			evaluator.WithEvaluator(
+				evaluator.SemVerEvaluationName,
+				evaluator.NewSemVerComparison(log).SemVerEvaluation,
+			),
+		)
+	}
+
+	compiled := newEvaluator(store.NewFlags())
+	if _, _, err := compiled.SetState(sync.DataSync{FlagData: TargetingBenchmarkFlags}); err != nil {
+		b.Fatalf("expected no error, got %v", err)
+	}
+
+	var flags evaluator.Flags
+	if err := json.Unmarshal([]byte(TargetingBenchmarkFlags), &flags); err != nil {
+		b.Fatalf("expected no error, got %v", err)
+	}
+	raw := store.NewFlags()
+	for key, flag := range flags.Flags {
+		raw.Set(key, flag)
+	}
+	uncompiled := newEvaluator(raw)
+
+	reqID := "test"
+	for _, test := range tests {
+		for name, je := range map[string]*evaluator.JSON{"compiled": compiled, "uncompiled": uncompiled} {
+			b.Run(fmt.Sprintf("%s %s", name, test.flagKey), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_, _, reason, _, err := je.ResolveBooleanValue(context.TODO(), reqID, test.flagKey, test.context)
+					if err != nil || reason != model.TargetingMatchReason {
+						b.Fatalf("unexpected evaluation result, reason: %s, err: %v", reason, err)
+					}
+				}
+			})
+		}
+
+		flag := flags.Flags[test.flagKey]
+		b.Run(fmt.Sprintf("marshalled %s", test.flagKey), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				variant, err := applyMarshalled(test.flagKey, flag.Targeting, test.context)
+				if _, ok := flag.Variants[variant]; err != nil || !ok {
+					b.Fatalf("unexpected evaluation result, variant: %s, err: %v", variant, err)
+				}
+			}
+		})
+	}
+}
+
+// applyMarshalled evaluates the targeting rule as done before rules were compiled: the rule and the context, along
+// with the flagd properties, are marshalled and parsed again by JSONLogic, and the variant is read from its output
+func applyMarshalled(flagKey string, targeting json.RawMessage, evalContext map[string]any) (string, error) {
+	rule, err := targeting.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	data := make(map[string]any, len(evalContext)+1)
+	for key, value := range evalContext {
+		data[key] = value
+	}
+	data["$flagd"] = map[string]any{"flagKey": flagKey, "timestamp": time.Now().Unix()}
+	marshalledData, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	var result bytes.Buffer
+	if err := jsonlogic.Apply(bytes.NewReader(rule), bytes.NewReader(marshalledData), &result); err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(strings.TrimSpace(result.String()), "\"", ""), nil
+}
+
 func TestResolveStringValue(t *testing.T) {
 	tests := []struct {
 		flagKey   string
@@ -452,7 +1104,10 @@ func TestResolveStringValue(t *testing.T) {
 		errorCode string
 	}{
 		{StaticStringFlag, nil, StaticStringValue, model.StaticReason, ""},
-		{DynamicStringFlag, map[string]interface{}{ColorProp: ColorValue}, DynamicStringValue, model.TargetingMatchReason, ""},
+		{
+			DynamicStringFlag, map[string]interface{}{ColorProp: ColorValue}, DynamicStringValue,
+			model.TargetingMatchReason, "",
+		},
 		{StaticObjectFlag, nil, "", model.ErrorReason, model.TypeMismatchErrorCode},
 		{MissingFlag, nil, "", model.ErrorReason, model.FlagNotFoundErrorCode},
 		{DisabledFlag, nil, "", model.ErrorReason, model.FlagDisabledErrorCode},
@@ -488,7 +1143,10 @@ func BenchmarkResolveStringValue(b *testing.B) {
 		errorCode string
 	}{
 		{StaticStringFlag, nil, StaticStringValue, model.StaticReason, ""},
-		{DynamicStringFlag, map[string]interface{}{ColorProp: ColorValue}, DynamicStringValue, model.TargetingMatchReason, ""},
+		{
+			DynamicStringFlag, map[string]interface{}{ColorProp: ColorValue}, DynamicStringValue,
+			model.TargetingMatchReason, "",
+		},
 		{StaticObjectFlag, nil, "", model.ErrorReason, model.TypeMismatchErrorCode},
 		{MissingFlag, nil, "", model.ErrorReason, model.FlagNotFoundErrorCode},
 		{DisabledFlag, nil, "", model.ErrorReason, model.FlagDisabledErrorCode},
@@ -1482,3 +2140,47 @@ func TestTargetingVariantBehavior(t *testing.T) {
 		}
 	})
 }
+
+func TestValidate(t *testing.T) {
+	je := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags())
+
+	assert.Empty(t, je.Validate(ValidFlags))
+
+	issues := je.Validate(`{
+  "flags": {
+    "a.flag": {
+      "state": "ON",
+      "variants": { "on": true, "off": false },
+      "defaultVariant": "missing"
+    },
+    "b": {
+      "state": "ENABLED",
+      "variants": { "on": true, "off": false },
+      "defaultVariant": "on",
+      "targeting": { "if": [ { "var": "email" }, "red", "off" ] },
+      "metadata": { "owner": { "team": "search" } }
+    }
+  }
+}`)
+
+	assert.Contains(t, issues, evaluator.ValidationIssue{
+		Path:    "flags.a.flag.state",
+		Message: `flags.a.flag.state must be one of the following: "ENABLED", "DISABLED"`,
+	})
+	assert.Contains(t, issues, evaluator.ValidationIssue{
+		Path:    "flags.a.flag.defaultVariant",
+		Message: "default variant: 'missing' isn't a valid variant of flag: 'a.flag'",
+	})
+	assert.Contains(t, issues, evaluator.ValidationIssue{
+		Path:    "flags.b.targeting",
+		Message: "invalid targeting of flag 'b': variant 'red' isn't a valid variant of the flag",
+	})
+	assert.Contains(t, issues, evaluator.ValidationIssue{
+		Path:    "flags.b.metadata.owner",
+		Message: "metadata 'owner' must be a string, a number or a boolean",
+	})
+
+	issues = je.Validate(`{ "flags": `)
+	assert.Len(t, issues, 1)
+	assert.Empty(t, issues[0].Path)
+}
@@ -0,0 +1,110 @@
+package evaluator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-feature/flagd/core/pkg/model"
+	"golang.org/x/exp/maps"
+)
+
+type metadataFilterContextKey struct{}
+
+// WithMetadataFilter returns a context narrowing the bulk evaluations performed with it down to the flags whose
+// metadata matches the filter. The filter is a comma separated list of key=value pairs, all of which must match; a key
+// without value only requires the flag to have that metadata key, e.g. owner=checkout,deprecated
+func WithMetadataFilter(ctx context.Context, filter string) context.Context {
+	return context.WithValue(ctx, metadataFilterContextKey{}, parseMetadataFilter(filter))
+}
+
+// metadataFilter maps metadata keys to their expected value, nil values only requiring the key to be present
+type metadataFilter map[string]*string
+
+func parseMetadataFilter(filter string) metadataFilter {
+	parsed := metadataFilter{}
+	for _, pair := range strings.Split(filter, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if !ok {
+			parsed[key] = nil
+			continue
+		}
+		value = strings.TrimSpace(value)
+		parsed[key] = &value
+	}
+	return parsed
+}
+
+// matches checks whether the metadata of the flag matches all entries of the filter, values being compared through
+// their string representation
+func (f metadataFilter) matches(flag model.Flag) bool {
+	for key, expected := range f {
+		value, ok := flag.Metadata[key]
+		if !ok {
+			return false
+		}
+		if expected != nil && fmt.Sprint(value) != *expected {
+			return false
+		}
+	}
+	return true
+}
+
+func metadataFilterFromContext(ctx context.Context) metadataFilter {
+	filter, _ := ctx.Value(metadataFilterContextKey{}).(metadataFilter)
+	return filter
+}
+
+// mergeMetadata merges the metadata of the flag set into the metadata of each flag, entries of the flag taking
+// precedence over those of the flag set
+func mergeMetadata(flags *Flags) {
+	if len(flags.Metadata) == 0 {
+		return
+	}
+
+	for key, flag := range flags.Flags {
+		metadata := maps.Clone(flags.Metadata)
+		maps.Copy(metadata, flag.Metadata)
+		flag.Metadata = metadata
+		flags.Flags[key] = flag
+	}
+}
+
+// validateMetadata returns an error if any metadata entry of the flag set or of its flags is not a string, a number or
+// a boolean, as required of evaluation metadata
+func validateMetadata(flags *Flags) error {
+	errs := validateMetadataValues("metadata", flags.Metadata)
+
+	keys := maps.Keys(flags.Flags)
+	sort.Strings(keys)
+	for _, key := range keys {
+		errs = append(errs, validateMetadataValues(flagPath(key, "metadata"), flags.Flags[key].Metadata)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateMetadataValues(path string, metadata map[string]interface{}) []error {
+	keys := maps.Keys(metadata)
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		switch metadata[key].(type) {
+		case string, float64, bool:
+		default:
+			errs = append(errs, &ValidationError{
+				Path: fmt.Sprintf("%s.%s", path, key),
+				Err:  fmt.Errorf("metadata '%s' must be a string, a number or a boolean", key),
+			})
+		}
+	}
+
+	return errs
+}
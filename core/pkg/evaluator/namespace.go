@@ -0,0 +1,26 @@
+package evaluator
+
+import (
+	"context"
+
+	"github.com/open-feature/flagd/core/pkg/store"
+)
+
+type namespaceContextKey struct{}
+
+// WithNamespace returns a context whose evaluations are performed against the flags of the namespace, the flags of
+// the default namespace being evaluated if the namespace is empty
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceContextKey{}, namespace)
+}
+
+// NamespaceFromContext returns the namespace of the evaluations performed with the context
+func NamespaceFromContext(ctx context.Context) string {
+	namespace, _ := ctx.Value(namespaceContextKey{}).(string)
+	return namespace
+}
+
+// namespaceStore returns the store of the namespace of the context, false if the namespace is unknown
+func (je *JSON) namespaceStore(ctx context.Context) (*store.Flags, bool) {
+	return je.store.LookupNamespace(NamespaceFromContext(ctx))
+}
@@ -0,0 +1,172 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+)
+
+const ProgressiveEvaluationName = "progressive"
+
+// Progressive rolls a variant out to a share of the evaluations which changes linearly over time, from a start weight
+// at the start time to an end weight at the end time. The remaining evaluations get the variant it replaces.
+// Evaluations are bucketed like fractional evaluations, so that the bucketing values the variant is rolled out to keep
+// it as its weight increases.
+type Progressive struct {
+	Logger *logger.Logger
+}
+
+type progressiveRollout struct {
+	variant     string
+	replaced    string
+	start       time.Time
+	end         time.Time
+	startWeight int
+	endWeight   int
+}
+
+func NewProgressive(logger *logger.Logger) *Progressive {
+	return &Progressive{Logger: logger}
+}
+
+func (pe *Progressive) Evaluate(values, data any) any {
+	valueToDistribute, rollout, now, err := parseProgressiveEvaluationData(values, data)
+	if err != nil {
+		pe.Logger.Error(fmt.Sprintf("parse progressive evaluation data: %v", err))
+		return nil
+	}
+
+	return rolloutVariant(valueToDistribute, rollout.variant, rollout.replaced, rollout.weight(now))
+}
+
+// weight returns the percentage of the evaluations the variant is rolled out to at the given time
+func (r progressiveRollout) weight(now time.Time) int {
+	return model.LinearWeight(r.start, r.end, r.startWeight, r.endWeight, now)
+}
+
+// rolloutVariant returns the variant served for the bucketing value while the variant is rolled out to the given
+// percentage of the evaluations, the others being served the replaced variant. Ramps of schedules and the progressive
+// operation share it, so that a flag ramps up the same way either way.
+func rolloutVariant(value string, variant string, replaced string, weight int) string {
+	return distributeValue(value, []fractionalEvaluationDistribution{
+		{variant: variant, percentage: weight},
+		{variant: replaced, percentage: 100 - weight},
+	})
+}
+
+func parseProgressiveEvaluationData(values, data any) (string, progressiveRollout, time.Time, error) {
+	valuesArray, ok := values.([]any)
+	if !ok {
+		return "", progressiveRollout{}, time.Time{}, errors.New("progressive evaluation data is not an array")
+	}
+	if len(valuesArray) < 3 {
+		return "", progressiveRollout{}, time.Time{}, errors.New("progressive evaluation data has length under 3")
+	}
+
+	dataMap, ok := data.(map[string]any)
+	if !ok {
+		return "", progressiveRollout{}, time.Time{}, errors.New("data isn't of type map[string]any")
+	}
+
+	valueToDistribute, valuesArray, err := bucketingValue(valuesArray, dataMap)
+	if err != nil {
+		return "", progressiveRollout{}, time.Time{}, err
+	}
+
+	rollout, err := parseProgressiveRollout(valuesArray)
+	if err != nil {
+		return "", progressiveRollout{}, time.Time{}, err
+	}
+
+	now := time.Now()
+	if properties, ok := getFlagdProperties(dataMap); ok {
+		now = time.Unix(properties.Timestamp, 0)
+	}
+
+	return valueToDistribute, rollout, now, nil
+}
+
+// parseProgressiveRollout parses the variants, times and weights of a rollout:
+// [[variant, replaced variant], [start, end], [start weight, end weight]]
+func parseProgressiveRollout(values []any) (progressiveRollout, error) {
+	if len(values) != 3 {
+		return progressiveRollout{}, errors.New("progressive evaluation data must hold variants, times and weights")
+	}
+
+	variants, err := parseProgressivePair(values[0], "variants")
+	if err != nil {
+		return progressiveRollout{}, err
+	}
+	times, err := parseProgressivePair(values[1], "times")
+	if err != nil {
+		return progressiveRollout{}, err
+	}
+	weights, err := parseProgressivePair(values[2], "weights")
+	if err != nil {
+		return progressiveRollout{}, err
+	}
+
+	var rollout progressiveRollout
+	var ok bool
+	if rollout.variant, ok = variants[0].(string); !ok {
+		return progressiveRollout{}, errors.New("variants aren't of type string")
+	}
+	if rollout.replaced, ok = variants[1].(string); !ok {
+		return progressiveRollout{}, errors.New("variants aren't of type string")
+	}
+	if rollout.start, err = parseProgressiveTime(times[0]); err != nil {
+		return progressiveRollout{}, err
+	}
+	if rollout.end, err = parseProgressiveTime(times[1]); err != nil {
+		return progressiveRollout{}, err
+	}
+	if !rollout.start.Before(rollout.end) {
+		return progressiveRollout{}, errors.New("start time must be before end time")
+	}
+	if rollout.startWeight, err = parseProgressiveWeight(weights[0]); err != nil {
+		return progressiveRollout{}, err
+	}
+	if rollout.endWeight, err = parseProgressiveWeight(weights[1]); err != nil {
+		return progressiveRollout{}, err
+	}
+
+	return rollout, nil
+}
+
+func parseProgressivePair(value any, name string) ([]any, error) {
+	pair, ok := value.([]any)
+	if !ok || len(pair) != 2 {
+		return nil, fmt.Errorf("%s aren't an array of length 2", name)
+	}
+	return pair, nil
+}
+
+// parseProgressiveTime parses Unix timestamps in seconds and RFC 3339 times
+func parseProgressiveTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case float64:
+		return time.Unix(int64(v), 0), nil
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time '%s': %w", v, err)
+		}
+		return t, nil
+	default:
+		return time.Time{}, fmt.Errorf("time %v is neither a timestamp nor an RFC 3339 string", value)
+	}
+}
+
+func parseProgressiveWeight(value any) (int, error) {
+	weight, ok := value.(float64)
+	if !ok {
+		return 0, errors.New("weights aren't of type float")
+	}
+	if weight < 0 || weight > 100 {
+		return 0, fmt.Errorf("weight %v isn't between 0 and 100", weight)
+	}
+	return int(weight), nil
+}
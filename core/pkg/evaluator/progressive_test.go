@@ -0,0 +1,182 @@
+package evaluator
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rolloutStart = 1700000000
+	rolloutEnd   = rolloutStart + 100
+)
+
+func TestProgressiveEvaluation(t *testing.T) {
+	tests := map[string]struct {
+		values    string
+		context   map[string]any
+		timestamp int64
+		want      any
+	}{
+		"before start": {
+			values:    `["user", ["new", "old"], [1700000000, 1700000100], [0, 100]]`,
+			timestamp: rolloutStart - 10,
+			want:      "old",
+		},
+		"after end": {
+			values:    `["user", ["new", "old"], [1700000000, 1700000100], [0, 100]]`,
+			timestamp: rolloutEnd + 10,
+			want:      "new",
+		},
+		"RFC 3339 times": {
+			values:    `["user", ["new", "old"], ["2023-11-14T22:13:20Z", "2023-11-14T22:15:00Z"], [0, 100]]`,
+			timestamp: rolloutEnd,
+			want:      "new",
+		},
+		"ramp down": {
+			values:    `["user", ["new", "old"], [1700000000, 1700000100], [100, 0]]`,
+			timestamp: rolloutEnd,
+			want:      "old",
+		},
+		"default to targetingKey": {
+			values:    `[["new", "old"], [1700000000, 1700000100], [0, 100]]`,
+			context:   map[string]any{targetingKeyKey: "user"},
+			timestamp: rolloutEnd,
+			want:      "new",
+		},
+		"no bucketing value": {
+			values:    `[["new", "old"], [1700000000, 1700000100], [0, 100]]`,
+			timestamp: rolloutEnd,
+			want:      nil,
+		},
+		"start after end": {
+			values:    `["user", ["new", "old"], [1700000100, 1700000000], [0, 100]]`,
+			timestamp: rolloutEnd,
+			want:      nil,
+		},
+		"weight out of range": {
+			values:    `["user", ["new", "old"], [1700000000, 1700000100], [0, 200]]`,
+			timestamp: rolloutEnd,
+			want:      nil,
+		},
+		"invalid time": {
+			values:    `["user", ["new", "old"], ["yesterday", 1700000100], [0, 100]]`,
+			timestamp: rolloutEnd,
+			want:      nil,
+		},
+		"missing weights": {
+			values:    `["user", ["new", "old"], [1700000000, 1700000100]]`,
+			timestamp: rolloutEnd,
+			want:      nil,
+		},
+	}
+
+	progressive := NewProgressive(logger.NewLogger(nil, false))
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var values any
+			require.NoError(t, json.Unmarshal([]byte(tt.values), &values))
+
+			assert.Equal(t, tt.want, progressive.Evaluate(values, progressiveData(tt.context, tt.timestamp)))
+		})
+	}
+}
+
+func TestProgressiveEvaluation_sticky(t *testing.T) {
+	var values any
+	require.NoError(t, json.Unmarshal([]byte(`[["new", "old"], [1700000000, 1700000100], [0, 100]]`), &values))
+	progressive := NewProgressive(logger.NewLogger(nil, false))
+
+	rolledOut := map[string]bool{}
+	for timestamp := int64(rolloutStart); timestamp <= rolloutEnd; timestamp += 10 {
+		count := 0
+		for i := 0; i < 1000; i++ {
+			key := fmt.Sprintf("user-%d", i)
+			variant := progressive.Evaluate(values, progressiveData(map[string]any{targetingKeyKey: key}, timestamp))
+			if variant == "new" {
+				rolledOut[key] = true
+				count++
+			} else {
+				assert.False(t, rolledOut[key], "%s rolled back at %d", key, timestamp)
+			}
+		}
+		// the share of the keys the variant is rolled out to follows its weight
+		assert.InDelta(t, timestamp-rolloutStart, count/10, 10, "at %d", timestamp)
+	}
+	assert.Len(t, rolledOut, 1000)
+}
+
+func TestProgressiveRollout_weight(t *testing.T) {
+	rollout := progressiveRollout{
+		start:       time.Unix(rolloutStart, 0),
+		end:         time.Unix(rolloutEnd, 0),
+		startWeight: 10,
+		endWeight:   60,
+	}
+
+	assert.Equal(t, 10, rollout.weight(time.Unix(rolloutStart-1, 0)))
+	assert.Equal(t, 10, rollout.weight(time.Unix(rolloutStart, 0)))
+	assert.Equal(t, 35, rollout.weight(time.Unix(rolloutStart+50, 0)))
+	assert.Equal(t, 60, rollout.weight(time.Unix(rolloutEnd, 0)))
+	assert.Equal(t, 60, rollout.weight(time.Unix(rolloutEnd+1, 0)))
+}
+
+func TestProgressiveEvaluation_scheduleRamp(t *testing.T) {
+	var values any
+	require.NoError(t, json.Unmarshal([]byte(`[["new", "old"], [1700000000, 1700000100], [0, 100]]`), &values))
+	progressive := NewProgressive(logger.NewLogger(nil, false))
+	flag := model.Flag{
+		DefaultVariant: "old",
+		Schedule: &model.Schedule{
+			Ramp: &model.Ramp{Variant: "new", Start: time.Unix(rolloutStart, 0), End: time.Unix(rolloutEnd, 0)},
+		},
+	}
+
+	// a flag ramps up the same way through the progressive operation and through the ramp of its schedule
+	for timestamp := int64(rolloutStart - 10); timestamp <= rolloutEnd+10; timestamp += 5 {
+		for i := 0; i < 100; i++ {
+			context := map[string]any{targetingKeyKey: fmt.Sprintf("user-%d", i)}
+			scheduled, _ := scheduledVariant("flag", flag, context, model.StaticReason, time.Unix(timestamp, 0))
+			require.Equal(t, scheduled, progressive.Evaluate(values, progressiveData(context, timestamp)),
+				"at %d for %s", timestamp, context[targetingKeyKey])
+		}
+	}
+
+	// keyed evaluations are split from the start of the ramp on, before any key is ramped up, as they are only notified
+	// at the start and at the end of the ramp
+	variant, reason := scheduledVariant(
+		"flag", flag, map[string]any{targetingKeyKey: "user"}, model.StaticReason, time.Unix(rolloutStart, 0))
+	require.Equal(t, "old", variant)
+	require.Equal(t, model.SplitReason, reason)
+}
+
+func TestValidateProgressiveRollout(t *testing.T) {
+	var valid, invalid any
+	require.NoError(t, json.Unmarshal(
+		[]byte(`[{"var": "email"}, ["new", "old"], [1700000000, 1700000100], [0, 100]]`), &valid,
+	))
+	require.NoError(t, json.Unmarshal([]byte(`[["new", "old"], [1700000000, 1700000100], [0, 101]]`), &invalid))
+
+	assert.NoError(t, validateProgressiveRollout(valid))
+	assert.Error(t, validateProgressiveRollout(invalid))
+	assert.Error(t, validateProgressiveRollout("new"))
+}
+
+func progressiveData(context map[string]any, timestamp int64) map[string]any {
+	data := map[string]any{
+		flagdPropertiesKey: map[string]any{
+			flagKeyPropertyKey:   "flag",
+			timestampPropertyKey: float64(timestamp),
+		},
+	}
+	for key, value := range context {
+		data[key] = value
+	}
+	return data
+}
@@ -0,0 +1,82 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/model"
+	"golang.org/x/exp/maps"
+)
+
+// scheduledVariant returns the variant served by default at the given time, with its reason. While the ramp of the
+// schedule of the flag is in progress, a growing share of the targeting keys is served the variant of the ramp
+// instead of the default variant, bucketed like fractional evaluations so that keys stay on the variant of the ramp.
+// These evaluations are reported with the split reason from the start of the ramp on, even before the first key is
+// ramped up, so that providers do not cache them: configuration changes are only notified at the start and at the
+// end of the ramp.
+func scheduledVariant(
+	flagKey string, flag model.Flag, context map[string]any, reason string, now time.Time,
+) (string, string) {
+	if flag.Schedule == nil || flag.Schedule.Ramp == nil {
+		return flag.DefaultVariant, reason
+	}
+
+	ramp := flag.Schedule.Ramp
+	switch {
+	case now.Before(ramp.Start):
+		return flag.DefaultVariant, reason
+	case !now.Before(ramp.End):
+		return ramp.Variant, reason
+	}
+
+	targetingKey, ok := context[targetingKeyKey].(string)
+	if !ok {
+		return flag.DefaultVariant, reason
+	}
+	return rolloutVariant(flagKey+targetingKey, ramp.Variant, flag.DefaultVariant, ramp.Weight(now)), model.SplitReason
+}
+
+// validateSchedules returns an error if any schedule deactivates its flag before activating it, or ramps up to an
+// invalid variant
+func validateSchedules(flags *Flags) error {
+	keys := maps.Keys(flags.Flags)
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		flag := flags.Flags[key]
+		schedule := flag.Schedule
+		if schedule == nil {
+			continue
+		}
+
+		path := flagPath(key, "schedule")
+		if schedule.Activate != nil && schedule.Deactivate != nil && !schedule.Activate.Before(*schedule.Deactivate) {
+			errs = append(errs, &ValidationError{
+				Path: path,
+				Err:  fmt.Errorf("schedule of flag '%s' deactivates it before activating it", key),
+			})
+		}
+		if schedule.Ramp == nil {
+			continue
+		}
+		if _, ok := flag.Variants[schedule.Ramp.Variant]; !ok {
+			errs = append(errs, &ValidationError{
+				Path: path + ".ramp.variant",
+				Err: fmt.Errorf(
+					"ramp variant: '%s' isn't a valid variant of flag: '%s'", schedule.Ramp.Variant, key,
+				),
+			})
+		}
+		if !schedule.Ramp.Start.Before(schedule.Ramp.End) {
+			errs = append(errs, &ValidationError{
+				Path: path + ".ramp",
+				Err:  fmt.Errorf("ramp of flag '%s' must start before it ends", key),
+			})
+		}
+	}
+
+	return errors.Join(errs...)
+}
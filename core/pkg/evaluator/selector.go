@@ -0,0 +1,62 @@
+package evaluator
+
+import (
+	"context"
+
+	"github.com/open-feature/flagd/core/pkg/model"
+)
+
+type selectorContextKey struct{}
+
+// WithSelector returns a context which narrows the flags seen by the evaluations performed with it down to the sources
+// matching the selector, either through the selector configured for them or through the source itself. Overrides
+// apply whatever the selector.
+func WithSelector(ctx context.Context, selector string) context.Context {
+	return context.WithValue(ctx, selectorContextKey{}, selector)
+}
+
+func selectorFromContext(ctx context.Context) string {
+	selector, _ := ctx.Value(selectorContextKey{}).(string)
+	return selector
+}
+
+// getFlag returns the flag as seen by the namespace and the selector of the context
+func (je *JSON) getFlag(ctx context.Context, flagKey string) (model.Flag, bool) {
+	flags, ok := je.namespaceStore(ctx)
+	if !ok {
+		return model.Flag{}, false
+	}
+	selector := selectorFromContext(ctx)
+	if selector == "" {
+		return flags.Get(flagKey)
+	}
+
+	flag, ok := flags.GetSelected(flagKey, selector)
+	if !ok {
+		return flag, false
+	}
+	if stored, _ := flags.Get(flagKey); model.IsOverrideSource(stored.Source) {
+		return stored, true
+	}
+	return flag, true
+}
+
+// getAllFlags returns the flags as seen by the namespace and the selector of the context
+func (je *JSON) getAllFlags(ctx context.Context) map[string]model.Flag {
+	flags, ok := je.namespaceStore(ctx)
+	if !ok {
+		return map[string]model.Flag{}
+	}
+	selector := selectorFromContext(ctx)
+	if selector == "" {
+		return flags.GetAll()
+	}
+
+	selected := flags.GetAllSelected(selector)
+	for key, stored := range flags.GetAll() {
+		if _, ok := selected[key]; ok && model.IsOverrideSource(stored.Source) {
+			selected[key] = stored
+		}
+	}
+	return selected
+}
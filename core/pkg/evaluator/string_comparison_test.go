@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -191,7 +192,9 @@ func TestJSONEvaluator_startsWithEvaluation(t *testing.T) {
 			)
 			je.store.Flags = tt.flags.Flags
 
-			value, variant, reason, _, err := resolve[string](reqID, tt.flagKey, tt.context, je.evaluateVariant)
+			value, variant, reason, _, err := resolve[string](
+				context.Background(), reqID, tt.flagKey, tt.context, je.evaluateVariant,
+			)
 
 			if value != tt.expectedValue {
 				t.Errorf("expected value '%s', got '%s'", tt.expectedValue, value)
@@ -394,7 +397,9 @@ func TestJSONEvaluator_endsWithEvaluation(t *testing.T) {
 
 			je.store.Flags = tt.flags.Flags
 
-			value, variant, reason, _, err := resolve[string](reqID, tt.flagKey, tt.context, je.evaluateVariant)
+			value, variant, reason, _, err := resolve[string](
+				context.Background(), reqID, tt.flagKey, tt.context, je.evaluateVariant,
+			)
 
 			if value != tt.expectedValue {
 				t.Errorf("expected value '%s', got '%s'", tt.expectedValue, value)
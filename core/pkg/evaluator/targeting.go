@@ -0,0 +1,118 @@
+package evaluator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/diegoholiveira/jsonlogic/v3"
+)
+
+// compileTargeting parses the targeting rule of a flag into its in-memory rule tree. The returned tree is nil if the
+// flag does not define any targeting. The tree is shared between concurrent evaluations and must not be modified.
+func compileTargeting(targeting json.RawMessage) (interface{}, error) {
+	var rule interface{}
+	if len(targeting) == 0 {
+		return rule, nil
+	}
+
+	if err := json.Unmarshal(targeting, &rule); err != nil {
+		return nil, fmt.Errorf("unmarshal targeting: %w", err)
+	}
+
+	// an empty rule is equivalent to no targeting at all
+	if r, ok := rule.(map[string]interface{}); ok && len(r) == 0 {
+		rule = nil
+	}
+
+	return rule, nil
+}
+
+// compileFlagsTargeting compiles the targeting rules of all given flags and stores the rule tree alongside the flag
+func compileFlagsTargeting(flags *Flags) error {
+	for key, flag := range flags.Flags {
+		rule, err := compileTargeting(flag.Targeting)
+		if err != nil {
+			return fmt.Errorf("compiling targeting of flag '%s': %w", key, err)
+		}
+
+		flag.CompiledTargeting = rule
+		flags.Flags[key] = flag
+	}
+
+	return nil
+}
+
+// applyTargeting walks the compiled rule tree against the normalized evaluation context. The result is the raw
+// JSONLogic output, where nil signals that the default variant should be used.
+func applyTargeting(rule interface{}, context map[string]any) (interface{}, error) {
+	result, err := jsonlogic.ApplyInterface(rule, context)
+	if err != nil {
+		return nil, fmt.Errorf("apply targeting: %w", err)
+	}
+
+	return result, nil
+}
+
+// targetingResultToVariant converts the output of a targeting rule to a variant name. Non string results (e.g.
+// booleans) are matched against variants using their JSON representation.
+func targetingResultToVariant(result interface{}) (string, error) {
+	if variant, ok := result.(string); ok {
+		return variant, nil
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("marshal targeting result: %w", err)
+	}
+
+	return strings.ReplaceAll(strings.TrimSpace(string(b)), "\"", ""), nil
+}
+
+// normalizeContext makes sure the evaluation context only consists of JSON types, which is what JSONLogic operates on.
+// Contexts received through the evaluation APIs already have this shape, so only values of other types are converted.
+// The given context is modified in place, so callers must pass a copy of the context provided by the user.
+func normalizeContext(context map[string]any) error {
+	for key, value := range context {
+		if isJSONValue(value) {
+			continue
+		}
+
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("marshal context value '%s': %w", key, err)
+		}
+
+		var normalized interface{}
+		if err := json.Unmarshal(b, &normalized); err != nil {
+			return fmt.Errorf("unmarshal context value '%s': %w", key, err)
+		}
+
+		context[key] = normalized
+	}
+
+	return nil
+}
+
+func isJSONValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil, bool, float64, string:
+		return true
+	case []interface{}:
+		for _, e := range v {
+			if !isJSONValue(e) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		for _, e := range v {
+			if !isJSONValue(e) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,333 @@
+package evaluator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/diegoholiveira/jsonlogic/v3"
+)
+
+// ExplainMetadataKey is the evaluation metadata key holding the trace of an explained evaluation
+const ExplainMetadataKey = "explain"
+
+// literalsKey is the key of the evaluation context the evaluated arguments of a traced operation are read from, see
+// literal
+const literalsKey = "$trace"
+
+type traceContextKey struct{}
+
+// Trace describes how the targeting rule of a flag was evaluated
+type Trace struct {
+	FlagKey string      `json:"flagKey"`
+	Steps   []TraceStep `json:"steps"`
+}
+
+// TraceStep is an operation visited while evaluating a targeting rule. Steps are recorded in the order of the rule,
+// each operation preceding the operations among its arguments, and their depth reflects the nesting of the operation
+// within the rule.
+type TraceStep struct {
+	Depth    int    `json:"depth"`
+	Operator string `json:"operator"`
+	// Inputs are the values the operation reads from the evaluation context, keyed by their path
+	Inputs map[string]interface{} `json:"inputs,omitempty"`
+	Result interface{}            `json:"result"`
+	// Bucket is the hash bucket in the range [0, 100] a fractional or progressive operation assigned the evaluation
+	// context to
+	Bucket *int   `json:"bucket,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// WithTrace returns a context which enables the tracing of the flag evaluation performed with it, along with the trace
+// filled by the evaluation. Bulk evaluations are not traced.
+func WithTrace(ctx context.Context) (context.Context, *Trace) {
+	trace := &Trace{Steps: []TraceStep{}}
+	return context.WithValue(ctx, traceContextKey{}, trace), trace
+}
+
+func traceFromContext(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceContextKey{}).(*Trace)
+	return trace
+}
+
+// withoutTrace returns a context which disables the tracing of evaluations
+func withoutTrace(ctx context.Context) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, (*Trace)(nil))
+}
+
+// AsMap returns the JSON representation of the trace, as used in evaluation metadata
+func (t *Trace) AsMap() (map[string]interface{}, error) {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("marshal trace: %w", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("unmarshal trace: %w", err)
+	}
+
+	return m, nil
+}
+
+// record evaluates the rule against the normalized context, recording its operations along with their results. Like
+// JSONLogic, the arguments of an operation are evaluated before the operation itself, except for the branches of if
+// operations which are not taken, and the arguments of and and or operations following the argument deciding their
+// result. Each operation is evaluated once, and the result of the rule is returned.
+func (t *Trace) record(rule interface{}, context map[string]any) (interface{}, error) {
+	if _, _, ok := operationOf(rule); !ok {
+		return applyTargeting(rule, context)
+	}
+
+	return t.visit(rule, context, 0)
+}
+
+func (t *Trace) visit(rule interface{}, context map[string]any, depth int) (interface{}, error) {
+	operator, values, _ := operationOf(rule)
+	index := len(t.Steps)
+	t.Steps = append(t.Steps, TraceStep{Depth: depth, Operator: operator})
+
+	args, inputs, err := t.visitArgs(operator, values, context, depth+1)
+	if err != nil {
+		// the error is recorded by the step of the argument
+		return nil, err
+	}
+
+	// the steps of the arguments have been appended in the meantime
+	step := &t.Steps[index]
+	step.Inputs = inputs
+	operation, data := map[string]interface{}{operator: args}, context
+	if !iterates(operator) {
+		operation, data = literal(operator, args, context)
+	}
+	result, err := jsonlogic.ApplyInterface(operation, data)
+	if err != nil {
+		step.Error = err.Error()
+		return nil, fmt.Errorf("apply targeting: %w", err)
+	}
+
+	step.Result = result
+	if operator == "var" {
+		step.Inputs = map[string]interface{}{varPath(values): result}
+	}
+	step.Bucket = bucketOf(operator, args, context)
+
+	return result, nil
+}
+
+// visitArgs evaluates the arguments of an operation, visiting the operations among them. The values the variables
+// among them read from the evaluation context are returned along with the evaluated arguments.
+func (t *Trace) visitArgs(
+	operator string, values interface{}, context map[string]any, depth int,
+) (interface{}, map[string]interface{}, error) {
+	if iterates(operator) {
+		// the operations nested in the arguments are evaluated for each element of an array, and are not traced
+		return values, nil, nil
+	}
+
+	inputs := map[string]interface{}{}
+	var args interface{}
+	var err error
+	switch v := values.(type) {
+	case map[string]interface{}:
+		args, err = t.visitArg(v, context, depth, inputs)
+	case []interface{}:
+		evaluated := make([]interface{}, len(v))
+		for i, arg := range v {
+			if operator == "if" && !ifVisits(evaluated, len(v), i) {
+				continue
+			}
+			if evaluated[i], err = t.visitArg(arg, context, depth, inputs); err != nil {
+				break
+			}
+			if decides(operator, evaluated[i]) {
+				evaluated = evaluated[:i+1]
+				break
+			}
+		}
+		args = evaluated
+	default:
+		args = values
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(inputs) == 0 {
+		return args, nil, nil
+	}
+
+	return args, inputs, nil
+}
+
+// visitArg evaluates an argument of an operation. Variables are not visited, as they are part of the inputs of their
+// operation.
+func (t *Trace) visitArg(
+	arg interface{}, context map[string]any, depth int, inputs map[string]interface{},
+) (interface{}, error) {
+	operator, values, ok := operationOf(arg)
+	switch {
+	case !ok:
+		return arg, nil
+	case operator == "var":
+		value, err := jsonlogic.ApplyInterface(arg, context)
+		if err != nil {
+			return nil, fmt.Errorf("apply targeting: %w", err)
+		}
+		inputs[varPath(values)] = value
+		return value, nil
+	default:
+		return t.visit(arg, context, depth)
+	}
+}
+
+// ifVisits reports whether the evaluation of an if operation of n arguments visits the argument at index i, given the
+// arguments evaluated before it. Conditions are evaluated until one holds, and only the branch of the condition
+// holding, or the last argument if none does, is evaluated.
+func ifVisits(evaluated []interface{}, n int, i int) bool {
+	for c := 0; c < i && c+1 < n; c += 2 {
+		if truthy(evaluated[c]) {
+			return i == c+1
+		}
+	}
+
+	return i%2 == 0
+}
+
+// decides reports whether the evaluated argument of an and or or operation decides its result, in which case JSONLogic
+// returns without considering the following arguments
+func decides(operator string, value interface{}) bool {
+	switch operator {
+	case "and":
+		switch v := value.(type) {
+		case bool:
+			return !v
+		case string:
+			return v == ""
+		case []interface{}:
+			return true
+		default:
+			return false
+		}
+	case "or":
+		return truthy(value)
+	default:
+		return false
+	}
+}
+
+// iterates reports whether an operation evaluates the operations nested in its arguments for each element of an array
+func iterates(operator string) bool {
+	switch operator {
+	case "filter", "map", "reduce", "all", "none", "some":
+		return true
+	default:
+		return false
+	}
+}
+
+// literal returns the operation applied to its evaluated arguments, along with the evaluation context to apply it
+// with. JSONLogic reads the arguments which are objects as operations, so evaluated arguments which are objects, such
+// as the value of a variable, are read from a copy of the evaluation context instead.
+func literal(
+	operator string, args interface{}, context map[string]any,
+) (map[string]interface{}, map[string]any) {
+	var literals []interface{}
+	reference := func(arg interface{}) interface{} {
+		if _, ok := arg.(map[string]interface{}); !ok {
+			return arg
+		}
+		literals = append(literals, arg)
+		return map[string]interface{}{"var": fmt.Sprintf("%s.%d", literalsKey, len(literals)-1)}
+	}
+
+	switch v := args.(type) {
+	case map[string]interface{}:
+		args = reference(v)
+	case []interface{}:
+		referenced := make([]interface{}, len(v))
+		for i, arg := range v {
+			referenced[i] = reference(arg)
+		}
+		args = referenced
+	}
+
+	operation := map[string]interface{}{operator: args}
+	if len(literals) == 0 {
+		return operation, context
+	}
+
+	data := make(map[string]any, len(context)+1)
+	for k, v := range context {
+		data[k] = v
+	}
+	data[literalsKey] = literals
+
+	return operation, data
+}
+
+// bucketOf returns the hash bucket a fractional or progressive operation assigned the evaluation context to, given the
+// evaluated arguments of the operation. nil is returned for other operations, and invalid ones.
+func bucketOf(operator string, args interface{}, context map[string]any) *int {
+	var valueToDistribute string
+	var err error
+	switch operator {
+	case FractionEvaluationName:
+		valueToDistribute, _, err = parseFractionalEvaluationData(args, context)
+	case ProgressiveEvaluationName:
+		valueToDistribute, _, _, err = parseProgressiveEvaluationData(args, context)
+	default:
+		return nil
+	}
+	if err != nil {
+		return nil
+	}
+
+	bucket := fractionalBucket(valueToDistribute)
+	return &bucket
+}
+
+// operationOf returns the operator and the arguments of a rule consisting of a single operation
+func operationOf(rule interface{}) (string, interface{}, bool) {
+	operation, ok := rule.(map[string]interface{})
+	if !ok || len(operation) != 1 {
+		return "", nil, false
+	}
+
+	for operator, values := range operation {
+		return operator, values, true
+	}
+
+	return "", nil, false
+}
+
+// varPath returns the path a var operation reads from the evaluation context
+func varPath(values interface{}) string {
+	args, ok := values.([]interface{})
+	if !ok {
+		return fmt.Sprint(values)
+	}
+	if len(args) == 0 {
+		return ""
+	}
+
+	return fmt.Sprint(args[0])
+}
+
+// truthy follows the JSONLogic definition of truthiness
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	default:
+		return false
+	}
+}
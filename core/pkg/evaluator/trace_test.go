@@ -0,0 +1,252 @@
+package evaluator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+const traceFlags = `{
+  "flags": {
+    "headerColor": {
+      "state": "ENABLED",
+      "variants": {
+        "red": "#FF0000",
+        "blue": "#0000FF",
+        "green": "#00FF00"
+      },
+      "defaultVariant": "red",
+      "targeting": {
+        "if": [
+          { "ends_with": [ { "var": "email" }, "@faas.com" ] },
+          "red",
+          { "fractional": [ { "var": "email" }, [ "blue", 50 ], [ "green", 50 ] ] }
+        ]
+      }
+    }
+  }
+}`
+
+func TestTrace(t *testing.T) {
+	log := logger.NewLogger(nil, false)
+	je := NewJSON(
+		log,
+		store.NewFlags(),
+		WithEvaluator(FractionEvaluationName, NewFractional(log).Evaluate),
+		WithEvaluator(EndsWithEvaluationName, NewStringComparisonEvaluator(log).EndsWithEvaluation),
+	)
+	_, _, err := je.SetState(sync.DataSync{FlagData: traceFlags})
+	require.NoError(t, err)
+
+	ctx, trace := WithTrace(context.Background())
+	value, variant, reason, _, err := je.ResolveStringValue(ctx, "", "headerColor", map[string]any{
+		"email": "user@example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.TargetingMatchReason, reason)
+
+	require.Equal(t, "headerColor", trace.FlagKey)
+	require.Len(t, trace.Steps, 3)
+
+	require.Equal(t, "if", trace.Steps[0].Operator)
+	require.Equal(t, 0, trace.Steps[0].Depth)
+	require.Equal(t, variant, trace.Steps[0].Result)
+
+	require.Equal(t, EndsWithEvaluationName, trace.Steps[1].Operator)
+	require.Equal(t, 1, trace.Steps[1].Depth)
+	require.Equal(t, map[string]interface{}{"email": "user@example.com"}, trace.Steps[1].Inputs)
+	require.Equal(t, false, trace.Steps[1].Result)
+
+	// the matching branch is visited, the skipped one is not
+	fractional := trace.Steps[2]
+	require.Equal(t, FractionEvaluationName, fractional.Operator)
+	require.Equal(t, variant, fractional.Result)
+	require.NotNil(t, fractional.Bucket)
+	require.Equal(t, fractionalBucket("headerColoruser@example.com"), *fractional.Bucket)
+
+	variants := map[string]string{"blue": "#0000FF", "green": "#00FF00"}
+	require.Equal(t, variants[variant], value)
+
+	m, err := trace.AsMap()
+	require.NoError(t, err)
+	require.Equal(t, "headerColor", m["flagKey"])
+}
+
+func TestTrace_bulkEvaluation(t *testing.T) {
+	je := NewJSON(logger.NewLogger(nil, false), store.NewFlags())
+	_, _, err := je.SetState(sync.DataSync{FlagData: traceFlags})
+	require.NoError(t, err)
+
+	ctx, trace := WithTrace(context.Background())
+	je.ResolveAllValues(ctx, "", map[string]any{"email": "user@faas.com"})
+
+	// bulk evaluations are not traced
+	require.Empty(t, trace.FlagKey)
+	require.Empty(t, trace.Steps)
+}
+
+func TestTrace_evaluatesOperationsOnce(t *testing.T) {
+	calls := 0
+	je := NewJSON(
+		logger.NewLogger(nil, false),
+		store.NewFlags(),
+		WithEvaluator("traceCount", func(values, _ interface{}) interface{} {
+			calls++
+			return values.([]interface{})[0]
+		}),
+	)
+	_, _, err := je.SetState(sync.DataSync{FlagData: `{
+  "flags": {
+    "nested": {
+      "state": "ENABLED",
+      "variants": { "on": true, "off": false },
+      "defaultVariant": "off",
+      "targeting": {
+        "if": [
+          { "and": [ { "traceCount": [ { "traceCount": [ true ] } ] }, { "in": [ "b", [ "a", "b" ] ] } ] },
+          { "traceCount": [ "on" ] },
+          { "traceCount": [ "off" ] }
+        ]
+      }
+    }
+  }
+}`})
+	require.NoError(t, err)
+
+	ctx, trace := WithTrace(context.Background())
+	value, variant, reason, _, err := je.ResolveBooleanValue(ctx, "", "nested", map[string]any{})
+	require.NoError(t, err)
+	require.True(t, value)
+	require.Equal(t, "on", variant)
+	require.Equal(t, model.TargetingMatchReason, reason)
+
+	// the branch which is not taken is not evaluated
+	require.Equal(t, 3, calls)
+
+	operators := make([]string, 0, len(trace.Steps))
+	depths := make([]int, 0, len(trace.Steps))
+	for _, step := range trace.Steps {
+		operators = append(operators, step.Operator)
+		depths = append(depths, step.Depth)
+	}
+	require.Equal(t, []string{"if", "and", "traceCount", "traceCount", "in", "traceCount"}, operators)
+	require.Equal(t, []int{0, 1, 2, 3, 2, 1}, depths)
+	require.Equal(t, "on", trace.Steps[0].Result)
+	require.Equal(t, true, trace.Steps[1].Result)
+}
+
+func TestTrace_error(t *testing.T) {
+	je := NewJSON(logger.NewLogger(nil, false), store.NewFlags())
+	_, _, err := je.SetState(sync.DataSync{FlagData: `{
+  "flags": {
+    "invalid": {
+      "state": "ENABLED",
+      "variants": { "on": true, "off": false },
+      "defaultVariant": "off",
+      "targeting": { "if": [ { "unknown": [ { "var": "email" }, 1 ] }, "on", "off" ] }
+    }
+  }
+}`})
+	require.NoError(t, err)
+
+	ctx, trace := WithTrace(context.Background())
+	_, _, reason, _, err := je.ResolveBooleanValue(ctx, "", "invalid", map[string]any{"email": "user@example.com"})
+	require.Error(t, err)
+	require.Equal(t, model.ErrorReason, reason)
+
+	require.Len(t, trace.Steps, 2)
+	require.Nil(t, trace.Steps[0].Result)
+	require.Equal(t, "unknown", trace.Steps[1].Operator)
+	require.Equal(t, map[string]interface{}{"email": "user@example.com"}, trace.Steps[1].Inputs)
+	require.NotEmpty(t, trace.Steps[1].Error)
+}
+
+func TestTrace_objectValues(t *testing.T) {
+	je := NewJSON(
+		logger.NewLogger(nil, false),
+		store.NewFlags(),
+		WithEvaluator("traceIsObject", func(values, _ interface{}) interface{} {
+			_, ok := values.([]interface{})[0].(map[string]interface{})
+			return ok
+		}),
+	)
+	_, _, err := je.SetState(sync.DataSync{FlagData: `{
+  "flags": {
+    "object": {
+      "state": "ENABLED",
+      "variants": { "on": true, "off": false },
+      "defaultVariant": "off",
+      "targeting": { "if": [ { "traceIsObject": [ { "var": "user" } ] }, "on", "off" ] }
+    }
+  }
+}`})
+	require.NoError(t, err)
+	// the value of the variable would be read as an operation if it was applied again
+	evalContext := map[string]any{"user": map[string]any{"tier": "gold"}}
+
+	_, wantVariant, wantReason, _, err := je.ResolveBooleanValue(context.Background(), "", "object", evalContext)
+	require.NoError(t, err)
+
+	ctx, trace := WithTrace(context.Background())
+	value, variant, reason, _, err := je.ResolveBooleanValue(ctx, "", "object", evalContext)
+	require.NoError(t, err)
+	require.True(t, value)
+	require.Equal(t, wantVariant, variant)
+	require.Equal(t, wantReason, reason)
+
+	require.Len(t, trace.Steps, 2)
+	require.Equal(t, "traceIsObject", trace.Steps[1].Operator)
+	require.Equal(t, map[string]interface{}{"user": map[string]any{"tier": "gold"}}, trace.Steps[1].Inputs)
+	require.Equal(t, true, trace.Steps[1].Result)
+}
+
+func TestTrace_shortCircuits(t *testing.T) {
+	calls := 0
+	je := NewJSON(
+		logger.NewLogger(nil, false),
+		store.NewFlags(),
+		WithEvaluator("traceCount", func(values, _ interface{}) interface{} {
+			calls++
+			return values.([]interface{})[0]
+		}),
+	)
+	_, _, err := je.SetState(sync.DataSync{FlagData: `{
+  "flags": {
+    "shortCircuit": {
+      "state": "ENABLED",
+      "variants": { "on": true, "off": false },
+      "defaultVariant": "off",
+      "targeting": {
+        "if": [
+          { "and": [ { "traceCount": [ false ] }, { "traceCount": [ true ] } ] },
+          "off",
+          { "or": [ { "traceCount": [ "" ] }, { "traceCount": [ "on" ] }, { "traceCount": [ "off" ] } ] }
+        ]
+      }
+    }
+  }
+}`})
+	require.NoError(t, err)
+
+	ctx, trace := WithTrace(context.Background())
+	value, variant, _, _, err := je.ResolveBooleanValue(ctx, "", "shortCircuit", map[string]any{})
+	require.NoError(t, err)
+	require.True(t, value)
+	require.Equal(t, "on", variant)
+
+	// the arguments following the one deciding the result of and and or are not evaluated
+	require.Equal(t, 3, calls)
+
+	operators := make([]string, 0, len(trace.Steps))
+	for _, step := range trace.Steps {
+		operators = append(operators, step.Operator)
+	}
+	require.Equal(t, []string{"if", "and", "traceCount", "or", "traceCount", "traceCount"}, operators)
+	require.Equal(t, false, trace.Steps[1].Result)
+	require.Equal(t, "on", trace.Steps[3].Result)
+}
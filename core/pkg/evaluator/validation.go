@@ -0,0 +1,318 @@
+package evaluator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/xeipuuv/gojsonschema"
+	"golang.org/x/exp/maps"
+)
+
+const refKey = "$ref"
+
+// ValidationError is an issue found at a specific location of a flag configuration
+type ValidationError struct {
+	// Path is the dot separated location of the issue within the configuration, e.g. flags.myFlag.targeting
+	Path string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationIssue is a single problem reported by Validate
+type ValidationIssue struct {
+	// Path is the dot separated location of the issue, it is empty for issues concerning the configuration as a whole
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// Validate checks a flag configuration without applying it. Contrary to SetState, all issues are reported, including
+// schema violations and invalid targeting rules, regardless of whether strict validation is enabled.
+func (je *JSON) Validate(config string) []ValidationIssue {
+	issues, err := je.schemaIssues(config)
+	if err != nil {
+		return append(issues, ValidationIssue{Message: err.Error()})
+	}
+
+	transposedConfig, err := je.transposeEvaluators(config)
+	if err != nil {
+		return append(issues, ValidationIssue{Message: fmt.Sprintf("transposing evaluators: %s", err)})
+	}
+
+	var flags Flags
+	if err := json.Unmarshal([]byte(transposedConfig), &flags); err != nil {
+		return append(issues, ValidationIssue{
+			Message: fmt.Sprintf("unmarshalling provided configurations: %s", err),
+		})
+	}
+
+	if err := compileFlagsTargeting(&flags); err != nil {
+		return append(issues, validationIssues(err)...)
+	}
+
+	issues = append(issues, validationIssues(validateDefaultVariants(&flags))...)
+	issues = append(issues, validationIssues(validateSchedules(&flags))...)
+	issues = append(issues, validationIssues(validateMetadata(&flags))...)
+	return append(issues, validationIssues(validateTargeting(&flags))...)
+}
+
+// schemaIssues returns the violations of the registered schema
+func (je *JSON) schemaIssues(config string) ([]ValidationIssue, error) {
+	compiledSchema := je.schema.Load()
+	if compiledSchema == nil {
+		return nil, nil
+	}
+
+	result, err := compiledSchema.Validate(gojsonschema.NewStringLoader(config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute JSON schema validation: %w", err)
+	}
+
+	issues := make([]ValidationIssue, 0, len(result.Errors()))
+	for _, resultErr := range result.Errors() {
+		path := resultErr.Field()
+		if path == gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+			path = ""
+		}
+		issues = append(issues, ValidationIssue{Path: path, Message: resultErr.Description()})
+	}
+
+	return issues, nil
+}
+
+// validationIssues flattens the given, possibly joined, error into issues
+func validationIssues(err error) []ValidationIssue {
+	if err == nil {
+		return nil
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var issues []ValidationIssue
+		for _, e := range joined.Unwrap() {
+			issues = append(issues, validationIssues(e)...)
+		}
+		return issues
+	}
+
+	issue := ValidationIssue{Message: err.Error()}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		issue.Path = validationErr.Path
+	}
+
+	return []ValidationIssue{issue}
+}
+
+// validateDefaultVariants returns an error if any of the default variants aren't valid
+func validateDefaultVariants(flags *Flags) error {
+	keys := maps.Keys(flags.Flags)
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		flag := flags.Flags[key]
+		if _, ok := flag.Variants[flag.DefaultVariant]; !ok {
+			errs = append(errs, &ValidationError{
+				Path: flagPath(key, "defaultVariant"),
+				Err: fmt.Errorf(
+					"default variant: '%s' isn't a valid variant of flag: '%s'", flag.DefaultVariant, key,
+				),
+			})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func flagPath(key string, field string) string {
+	return fmt.Sprintf("flags.%s.%s", key, field)
+}
+
+// validateTargeting statically checks the compiled targeting rules of all flags, so that broken flags are detected when
+// flags are synced rather than when they are evaluated.
+func validateTargeting(flags *Flags) error {
+	keys := maps.Keys(flags.Flags)
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		flag := flags.Flags[key]
+		if flag.CompiledTargeting == nil {
+			continue
+		}
+
+		path := flagPath(key, "targeting")
+		for _, err := range validateRule(flag.CompiledTargeting) {
+			errs = append(errs, &ValidationError{
+				Path: path,
+				Err:  fmt.Errorf("invalid targeting of flag '%s': %w", key, err),
+			})
+		}
+
+		for _, variant := range returnedVariants(flag.CompiledTargeting) {
+			if _, ok := flag.Variants[variant]; !ok {
+				errs = append(errs, &ValidationError{
+					Path: path,
+					Err: fmt.Errorf(
+						"invalid targeting of flag '%s': variant '%s' isn't a valid variant of the flag", key, variant,
+					),
+				})
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateRule walks the rule tree looking for references to unknown evaluators, fractional evaluations whose
+// weights do not sum up to 100 and invalid progressive rollouts
+func validateRule(rule interface{}) []error {
+	var errs []error
+
+	switch r := rule.(type) {
+	case []interface{}:
+		for _, e := range r {
+			errs = append(errs, validateRule(e)...)
+		}
+	case map[string]interface{}:
+		for operator, values := range r {
+			switch operator {
+			case refKey:
+				errs = append(errs, fmt.Errorf("reference to unknown evaluator '%v'", values))
+			case FractionEvaluationName:
+				if err := validateFractionalWeights(values); err != nil {
+					errs = append(errs, err)
+				}
+			case ProgressiveEvaluationName:
+				if err := validateProgressiveRollout(values); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			errs = append(errs, validateRule(values)...)
+		}
+	}
+
+	return errs
+}
+
+func validateFractionalWeights(values interface{}) error {
+	distributions, ok := values.([]interface{})
+	if !ok {
+		return errors.New("fractional evaluation data is not an array")
+	}
+
+	sumOfPercentages := 0
+	for _, d := range distributions {
+		distribution, ok := d.([]interface{})
+		if !ok || len(distribution) != 2 {
+			// the bucketing value
+			continue
+		}
+
+		percentage, ok := distribution[1].(float64)
+		if !ok {
+			return errors.New("second element of distribution element isn't float")
+		}
+		sumOfPercentages += int(percentage)
+	}
+
+	if sumOfPercentages != 100 {
+		return fmt.Errorf("fractional percentages must sum to 100, got: %d", sumOfPercentages)
+	}
+
+	return nil
+}
+
+func validateProgressiveRollout(values interface{}) error {
+	args, ok := values.([]interface{})
+	if !ok {
+		return errors.New("progressive evaluation data is not an array")
+	}
+	if len(args) > 0 {
+		if _, ok := args[0].([]interface{}); !ok {
+			// the bucketing value
+			args = args[1:]
+		}
+	}
+
+	if _, err := parseProgressiveRollout(args); err != nil {
+		return fmt.Errorf("invalid progressive evaluation: %w", err)
+	}
+	return nil
+}
+
+// returnedVariants collects the literal variant names a rule can return. Results which depend on the evaluation context
+// are ignored. Shared evaluators referenced through $ref are inlined by transposeEvaluators before the rule is compiled,
+// hence the variants they return are covered as well.
+func returnedVariants(rule interface{}) []string {
+	switch r := rule.(type) {
+	case string:
+		return []string{r}
+	case bool:
+		return []string{strconv.FormatBool(r)}
+	case float64:
+		return []string{strconv.FormatFloat(r, 'f', -1, 64)}
+	case map[string]interface{}:
+		var variants []string
+		for operator, values := range r {
+			for _, result := range operationResults(operator, values) {
+				variants = append(variants, returnedVariants(result)...)
+			}
+		}
+		return variants
+	default:
+		return nil
+	}
+}
+
+// operationResults returns the arguments of an operation which can become its result
+func operationResults(operator string, values interface{}) []interface{} {
+	args, ok := values.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var results []interface{}
+	switch operator {
+	case "if", "?:":
+		// values at odd indices and the trailing else branch are results, the others are conditions
+		for i := 1; i < len(args); i += 2 {
+			results = append(results, args[i])
+		}
+		if len(args)%2 == 1 {
+			results = append(results, args[len(args)-1])
+		}
+	case "or", "and":
+		results = args
+	case "var":
+		// the default value is returned if the variable is not set in the context
+		if len(args) == 2 {
+			results = append(results, args[1])
+		}
+	case FractionEvaluationName:
+		for _, arg := range args {
+			if distribution, ok := arg.([]interface{}); ok && len(distribution) == 2 {
+				results = append(results, distribution[0])
+			}
+		}
+	case ProgressiveEvaluationName:
+		for _, arg := range args {
+			// the variants are the first pair of the rollout, the bucketing value is never an array
+			if variants, ok := arg.([]interface{}); ok {
+				results = append(results, variants...)
+				break
+			}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,73 @@
+package evaluator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturnedVariants(t *testing.T) {
+	tests := map[string]struct {
+		rule string
+		want []string
+	}{
+		"literal": {
+			rule: `"on"`,
+			want: []string{"on"},
+		},
+		"boolean literal": {
+			rule: `true`,
+			want: []string{"true"},
+		},
+		"if with else": {
+			rule: `{"if": [{"==": [{"var": "email"}, "a@b.c"]}, "on", "off"]}`,
+			want: []string{"on", "off"},
+		},
+		"if with multiple conditions": {
+			rule: `{"if": [{"var": "a"}, "a", {"var": "b"}, "b"]}`,
+			want: []string{"a", "b"},
+		},
+		"nested if": {
+			rule: `{"if": [{"var": "a"}, {"if": [{"var": "b"}, "b", "c"]}, "d"]}`,
+			want: []string{"b", "c", "d"},
+		},
+		"ternary": {
+			rule: `{"?:": [{"var": "a"}, "on", "off"]}`,
+			want: []string{"on", "off"},
+		},
+		"or": {
+			rule: `{"or": [{"var": "variant"}, "fallback"]}`,
+			want: []string{"fallback"},
+		},
+		"var default": {
+			rule: `{"var": ["variant", "fallback"]}`,
+			want: []string{"fallback"},
+		},
+		"var without default": {
+			rule: `{"var": "variant"}`,
+			want: nil,
+		},
+		"fractional": {
+			rule: `{"fractional": [{"var": "email"}, ["red", 50], ["blue", 50]]}`,
+			want: []string{"red", "blue"},
+		},
+		"progressive": {
+			rule: `{"progressive": [{"var": "email"}, ["new", "old"], [1700000000, 1700086400], [0, 100]]}`,
+			want: []string{"new", "old"},
+		},
+		"conditions are ignored": {
+			rule: `{"if": [{"in": ["x", {"var": "list"}]}, "on"]}`,
+			want: []string{"on"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var rule interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.rule), &rule))
+
+			require.ElementsMatch(t, tt.want, returnedVariants(rule))
+		})
+	}
+}
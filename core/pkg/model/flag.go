@@ -8,6 +8,14 @@ type Flag struct {
 	Variants       map[string]any  `json:"variants"`
 	Targeting      json.RawMessage `json:"targeting,omitempty"`
 	Source         string          `json:"source"`
+	// Metadata describes the flag, such as its owner or expiry date. It is returned in the metadata of its evaluations.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// Schedule enables and disables the flag at given times, and ramps its evaluations up to a variant
+	Schedule *Schedule `json:"schedule,omitempty"`
+
+	// CompiledTargeting holds the parsed rule tree of Targeting. It is populated by the evaluator when flags are
+	// synced, so that targeting rules do not need to be decoded again on every evaluation.
+	CompiledTargeting interface{} `json:"-"`
 }
 
 type Evaluators struct {
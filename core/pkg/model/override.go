@@ -0,0 +1,33 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// OverrideSource is the flag source holding the runtime overrides of the flags of the default namespace, it must be
+// the source of highest priority of the store. The overrides of other namespaces are held by OverrideSourceOf them.
+const OverrideSource = "flagd-override"
+
+// Override forces a flag to a variant, or disables it, until it is deleted or expires
+type Override struct {
+	FlagKey   string     `json:"flagKey"`
+	Namespace string     `json:"namespace,omitempty"`
+	Variant   string     `json:"variant,omitempty"`
+	Disabled  bool       `json:"disabled,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// OverrideSourceOf returns the flag source holding the runtime overrides of the flags of the namespace
+func OverrideSourceOf(namespace string) string {
+	if namespace == "" {
+		return OverrideSource
+	}
+	return OverrideSource + "/" + namespace
+}
+
+// IsOverrideSource checks whether the flag source holds the runtime overrides of a namespace
+func IsOverrideSource(source string) bool {
+	return source == OverrideSource || strings.HasPrefix(source, OverrideSource+"/")
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// Schedule changes the state of a flag at given times, and ramps evaluations up to a variant between two times
+type Schedule struct {
+	// Activate is the time the flag is enabled at, the flag being treated as disabled before
+	Activate *time.Time `json:"activate,omitempty"`
+	// Deactivate is the time the flag is disabled at, the flag being treated as disabled from then on
+	Deactivate *time.Time `json:"deactivate,omitempty"`
+	Ramp       *Ramp      `json:"ramp,omitempty"`
+}
+
+// Ramp linearly shifts the evaluations served the default variant of a flag over to Variant between Start and End
+type Ramp struct {
+	Variant string    `json:"variant"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+// Active checks whether the schedule enables the flag at the given time
+func (s *Schedule) Active(now time.Time) bool {
+	if s == nil {
+		return true
+	}
+	if s.Activate != nil && now.Before(*s.Activate) {
+		return false
+	}
+	return s.Deactivate == nil || now.Before(*s.Deactivate)
+}
+
+// Weight returns the percentage of evaluations served the variant of the ramp at the given time, in the range
+// [0, 100]
+func (r *Ramp) Weight(now time.Time) int {
+	if r == nil {
+		return 0
+	}
+	return LinearWeight(r.Start, r.End, 0, 100, now)
+}
+
+// LinearWeight returns the weight changing linearly from startWeight at start to endWeight at end, truncated to a
+// whole percentage. It is startWeight before start and endWeight from end on. Ramps of schedules and the progressive
+// targeting operation both weigh their variant this way, so that a flag ramps up the same way either way.
+func LinearWeight(start time.Time, end time.Time, startWeight int, endWeight int, now time.Time) int {
+	switch {
+	case !now.After(start):
+		return startWeight
+	case !now.Before(end):
+		return endWeight
+	}
+
+	return startWeight + int(float64(endWeight-startWeight)*float64(now.Sub(start))/float64(end.Sub(start)))
+}
+
+// Transitions returns the sorted times at which the schedule changes the evaluations of the flag: its activation,
+// its deactivation, and the start and end of its ramp. Evaluations changing while the ramp progresses are not cached
+// by providers, the ramp therefore has no transition in between.
+func (s *Schedule) Transitions() []time.Time {
+	if s == nil {
+		return nil
+	}
+
+	var transitions []time.Time
+	for _, t := range []*time.Time{s.Activate, s.Deactivate} {
+		if t != nil {
+			transitions = append(transitions, *t)
+		}
+	}
+	if s.Ramp != nil {
+		transitions = append(transitions, s.Ramp.Start, s.Ramp.End)
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].Before(transitions[j])
+	})
+
+	return transitions
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	end := start.Add(10 * 24 * time.Hour)
+	schedule := &Schedule{
+		Activate:   &start,
+		Deactivate: &end,
+		Ramp:       &Ramp{Variant: "on", Start: start, End: start.Add(4 * time.Hour)},
+	}
+
+	require.False(t, schedule.Active(start.Add(-time.Second)))
+	require.True(t, schedule.Active(start))
+	require.False(t, schedule.Active(end))
+	require.True(t, (*Schedule)(nil).Active(start))
+
+	require.Equal(t, 0, schedule.Ramp.Weight(start.Add(-time.Hour)))
+	require.Equal(t, 0, schedule.Ramp.Weight(start))
+	require.Equal(t, 25, schedule.Ramp.Weight(start.Add(time.Hour)))
+	require.Equal(t, 100, schedule.Ramp.Weight(start.Add(4*time.Hour)))
+	require.Equal(t, 0, (*Ramp)(nil).Weight(start))
+
+	require.Equal(t, []time.Time{start, start, start.Add(4 * time.Hour), end}, schedule.Transitions())
+	require.Empty(t, (*Schedule)(nil).Transitions())
+}
@@ -3,21 +3,30 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/open-feature/flagd/core/pkg/evaluator"
 	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
 	"github.com/open-feature/flagd/core/pkg/service"
 	flageval "github.com/open-feature/flagd/core/pkg/service/flag-evaluation"
+	"github.com/open-feature/flagd/core/pkg/snapshot"
 	"github.com/open-feature/flagd/core/pkg/store"
 	"github.com/open-feature/flagd/core/pkg/sync"
 	syncbuilder "github.com/open-feature/flagd/core/pkg/sync/builder"
+	"github.com/open-feature/flagd/core/pkg/sync/override"
 	"github.com/open-feature/flagd/core/pkg/telemetry"
 	"go.uber.org/zap"
 )
 
 // from_config is a collection of structures and parsers responsible for deriving flagd runtime
 
-const svcName = "flagd"
+const (
+	svcName = "flagd"
+
+	// overrideProvider is the provider of the runtime overrides source
+	overrideProvider = "override"
+)
 
 // Config is the configuration structure derived from startup arguments.
 type Config struct {
@@ -29,8 +38,17 @@ type Config struct {
 	ServicePort       uint16
 	ServiceSocketPath string
 
-	SyncProviders []sync.SourceConfig
-	CORS          []string
+	SyncProviders    []sync.SourceConfig
+	CORS             []string
+	StrictValidation bool
+
+	EventQueueSize      int
+	EventOverflowPolicy string
+
+	// AdminToken enables the flag override API of the management port
+	AdminToken string
+	// SnapshotDir enables persisting the flag configuration of each source, which is loaded on startup
+	SnapshotDir string
 }
 
 // FromConfig builds a runtime from startup configurations
@@ -56,24 +74,41 @@ func FromConfig(logger *logger.Logger, version string, config Config) (*Runtime,
 		return nil, fmt.Errorf("error building metrics recorder: %w", err)
 	}
 
-	// build flag store & fill sources details
-	s := store.NewFlags()
-	for _, provider := range config.SyncProviders {
-		s.FlagSources = append(s.FlagSources, provider.URI)
-		s.SourceMetadata[provider.URI] = store.SourceDetails{
-			Source:   provider.URI,
-			Selector: provider.Selector,
-		}
-	}
-
 	// derive evaluator
-	evaluator := setupJSONEvaluator(logger, s)
+	var evaluatorOpts []evaluator.JSONEvaluatorOption
+	if config.StrictValidation {
+		evaluatorOpts = append(evaluatorOpts, evaluator.WithStrictValidation())
+	}
+	flagStore := NewStore(config.SyncProviders)
+	evaluator := setupJSONEvaluator(logger, flagStore, evaluatorOpts...)
+
+	// overrides are a source of their own, taking precedence over all configured sources
+	sources := slices.Clone(config.SyncProviders)
+	var overrides *override.Sync
+	if config.AdminToken != "" {
+		registerOverrideSources(flagStore)
+		overrides = override.NewSync(logger.WithFields(zap.String("component", "overrides")), flagStore)
+		sources = append(sources, sync.SourceConfig{URI: model.OverrideSource, Provider: overrideProvider})
+	}
 
 	// derive service
+	overflowPolicy := flageval.OverflowCoalesce
+	if config.EventOverflowPolicy != "" {
+		overflowPolicy, err = flageval.ParseOverflowPolicy(config.EventOverflowPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event configuration: %w", err)
+		}
+	}
+	eventQueueSize := config.EventQueueSize
+	if eventQueueSize <= 0 {
+		eventQueueSize = flageval.DefaultEventQueueSize
+	}
+
 	connectService := flageval.NewConnectService(
 		logger.WithFields(zap.String("component", "service")),
 		evaluator,
-		recorder)
+		recorder,
+		flageval.WithEventQueue(eventQueueSize, overflowPolicy))
 
 	// build sync providers
 	syncLogger := logger.WithFields(zap.String("component", "sync"))
@@ -81,38 +116,106 @@ func FromConfig(logger *logger.Logger, version string, config Config) (*Runtime,
 	if err != nil {
 		return nil, err
 	}
+	if overrides != nil {
+		iSyncs = append(iSyncs, overrides)
+	}
+
+	var snapshots *snapshot.Dir
+	if config.SnapshotDir != "" {
+		if snapshots, err = snapshot.NewDir(config.SnapshotDir); err != nil {
+			return nil, fmt.Errorf("invalid snapshot configuration: %w", err)
+		}
+	}
 
 	options, err := telemetry.BuildConnectOptions(telCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build connect options, %w", err)
 	}
 
+	serviceConfig := service.Configuration{
+		Port:           config.ServicePort,
+		ManagementPort: config.ManagementPort,
+		ServiceName:    svcName,
+		KeyPath:        config.ServiceKeyPath,
+		CertPath:       config.ServiceCertPath,
+		SocketPath:     config.ServiceSocketPath,
+		CORS:           config.CORS,
+		Options:        options,
+		FlagStore:      flagStore,
+		AdminToken:     config.AdminToken,
+	}
+	if overrides != nil {
+		serviceConfig.Overrides = overrides
+	}
+
 	return &Runtime{
-		Logger:    logger.WithFields(zap.String("component", "runtime")),
-		Evaluator: evaluator,
-		Service:   connectService,
-		ServiceConfig: service.Configuration{
-			Port:           config.ServicePort,
-			ManagementPort: config.ManagementPort,
-			ServiceName:    svcName,
-			KeyPath:        config.ServiceKeyPath,
-			CertPath:       config.ServiceCertPath,
-			SocketPath:     config.ServiceSocketPath,
-			CORS:           config.CORS,
-			Options:        options,
-		},
-		SyncImpl: iSyncs,
+		Logger:        logger.WithFields(zap.String("component", "runtime")),
+		Evaluator:     evaluator,
+		Metrics:       recorder,
+		Service:       connectService,
+		ServiceConfig: serviceConfig,
+		SyncImpl:      iSyncs,
+		Sources:       sources,
+		Store:         flagStore,
+		Snapshots:     snapshots,
 	}, nil
 }
 
-func setupJSONEvaluator(logger *logger.Logger, s *store.Flags) *evaluator.JSON {
-	evaluator := evaluator.NewJSON(
-		logger,
-		s,
+// NewEvaluator builds the flag store for the given sources along with the JSON evaluator operating on it
+func NewEvaluator(
+	logger *logger.Logger, sources []sync.SourceConfig, opts ...evaluator.JSONEvaluatorOption,
+) *evaluator.JSON {
+	return setupJSONEvaluator(logger, NewStore(sources), opts...)
+}
+
+// NewStore builds the flag store for the given sources, filling the details of each source. Sources of a namespace
+// are recorded in the store of their namespace as well, which defines their priority within the namespace.
+func NewStore(sources []sync.SourceConfig) *store.Flags {
+	s := store.NewFlags()
+	for _, provider := range sources {
+		details := store.SourceDetails{
+			Source:    provider.URI,
+			Selector:  provider.Selector,
+			Namespace: provider.Namespace,
+		}
+		s.FlagSources = append(s.FlagSources, provider.URI)
+		s.SourceMetadata[provider.URI] = details
+		if provider.Namespace != "" {
+			ns := s.Namespace(provider.Namespace)
+			ns.FlagSources = append(ns.FlagSources, provider.URI)
+			ns.SourceMetadata[provider.URI] = details
+		}
+	}
+
+	return s
+}
+
+// registerOverrideSources registers the override source of each namespace as the source of highest priority of the
+// namespace
+func registerOverrideSources(s *store.Flags) {
+	for _, namespace := range s.Namespaces() {
+		source := model.OverrideSourceOf(namespace)
+		details := store.SourceDetails{Source: source, Namespace: namespace}
+		s.SourceMetadata[source] = details
+
+		ns := s.Namespace(namespace)
+		ns.FlagSources = append(ns.FlagSources, source)
+		ns.SourceMetadata[source] = details
+	}
+}
+
+func setupJSONEvaluator(
+	logger *logger.Logger, s *store.Flags, opts ...evaluator.JSONEvaluatorOption,
+) *evaluator.JSON {
+	opts = append([]evaluator.JSONEvaluatorOption{
 		evaluator.WithEvaluator(
 			evaluator.FractionEvaluationName,
 			evaluator.NewFractional(logger).Evaluate,
 		),
+		evaluator.WithEvaluator(
+			evaluator.ProgressiveEvaluationName,
+			evaluator.NewProgressive(logger).Evaluate,
+		),
 		evaluator.WithEvaluator(
 			evaluator.StartsWithEvaluationName,
 			evaluator.NewStringComparisonEvaluator(logger).StartsWithEvaluation,
@@ -130,8 +233,9 @@ func setupJSONEvaluator(logger *logger.Logger, s *store.Flags) *evaluator.JSON {
 			evaluator.LegacyFractionEvaluationName,
 			evaluator.NewLegacyFractional(logger).LegacyFractionalEvaluation,
 		),
-	)
-	return evaluator
+	}, opts...)
+
+	return evaluator.NewJSON(logger, s, opts...)
 }
 
 // syncProvidersFromConfig is a helper to build ISync implementations from SourceConfig
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
 	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,3 +16,17 @@ func Test_setupJSONEvaluator(t *testing.T) {
 	je := setupJSONEvaluator(lg, store.NewFlags())
 	require.NotNil(t, je)
 }
+
+func Test_registerOverrideSources(t *testing.T) {
+	s := NewStore([]sync.SourceConfig{
+		{URI: "A"},
+		{URI: "B", Namespace: "team-b"},
+	})
+	registerOverrideSources(s)
+
+	// the override source of each namespace takes precedence over the sources of the namespace
+	require.Equal(t, []string{"A", "B", model.OverrideSource}, s.FlagSources)
+	require.Equal(t, []string{"B", "flagd-override/team-b"}, s.Namespace("team-b").FlagSources)
+	require.Equal(t, "", s.NamespaceOf(model.OverrideSource))
+	require.Equal(t, "team-b", s.NamespaceOf("flagd-override/team-b"))
+}
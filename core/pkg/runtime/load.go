@@ -0,0 +1,78 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/sync"
+)
+
+// LoadSources fetches the current flag configuration of every source once and applies it to the evaluator. Contrary
+// to Start, sources are not watched for changes, which makes it suitable for one-off evaluations.
+func LoadSources(
+	ctx context.Context, logger *logger.Logger, je evaluator.IEvaluator, sources []sync.SourceConfig,
+) error {
+	iSyncs, err := syncProvidersFromConfig(logger, sources)
+	if err != nil {
+		return err
+	}
+
+	for i, s := range iSyncs {
+		if err := s.Init(ctx); err != nil {
+			return fmt.Errorf("sync provider Init returned error: %w", err)
+		}
+
+		data, err := firstDataSync(ctx, s)
+		closeSync(logger, s)
+		if err != nil {
+			return fmt.Errorf("error loading source %s: %w", sources[i].URI, err)
+		}
+
+		if _, _, err := je.SetState(data); err != nil {
+			return fmt.Errorf("rejected flag configuration from source %s: %w", data.Source, err)
+		}
+	}
+
+	return nil
+}
+
+// firstDataSync runs the sync provider until it delivers its first data sync, which holds the full flag configuration
+func firstDataSync(ctx context.Context, s sync.ISync) (sync.DataSync, error) {
+	syncCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// buffered, so that the provider is not blocked on sends which happen after the first one was received
+	dataSync := make(chan sync.DataSync, 1)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Sync(syncCtx, dataSync)
+	}()
+
+	select {
+	case data := <-dataSync:
+		return data, nil
+	case err := <-errs:
+		if err == nil {
+			err = errors.New("sync provider stopped without providing flags")
+		}
+		return sync.DataSync{}, err
+	case <-ctx.Done():
+		return sync.DataSync{}, fmt.Errorf("waiting for flags: %w", ctx.Err())
+	}
+}
+
+// closeSync releases the resources of sync providers holding some beyond their Sync call, such as the local repository
+// of git sources
+func closeSync(logger *logger.Logger, s sync.ISync) {
+	closer, ok := s.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		logger.Warn(fmt.Sprintf("error closing sync provider: %v", err))
+	}
+}
@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+const loadFlags = `{
+  "flags": {
+    "myBoolFlag": {
+      "state": "ENABLED",
+      "variants": {
+        "on": true,
+        "off": false
+      },
+      "defaultVariant": "on"
+    }
+  }
+}`
+
+func TestLoadSources(t *testing.T) {
+	lg := logger.NewLogger(nil, false)
+	path := filepath.Join(t.TempDir(), "flags.json")
+	require.NoError(t, os.WriteFile(path, []byte(loadFlags), 0o600))
+
+	sources := []sync.SourceConfig{{URI: path, Provider: "file"}}
+	je := NewEvaluator(lg, sources)
+	require.NoError(t, LoadSources(context.Background(), lg, je, sources))
+
+	value, variant, reason, _, err := je.ResolveBooleanValue(context.Background(), "", "myBoolFlag", nil)
+	require.NoError(t, err)
+	require.True(t, value)
+	require.Equal(t, "on", variant)
+	require.Equal(t, model.StaticReason, reason)
+}
+
+func TestLoadSources_missingFile(t *testing.T) {
+	lg := logger.NewLogger(nil, false)
+	sources := []sync.SourceConfig{{URI: filepath.Join(t.TempDir(), "missing.json"), Provider: "file"}}
+
+	err := LoadSources(context.Background(), lg, NewEvaluator(lg, sources), sources)
+	require.Error(t, err)
+}
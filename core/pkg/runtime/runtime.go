@@ -8,22 +8,38 @@ import (
 	"os/signal"
 	msync "sync"
 	"syscall"
+	"time"
 
 	"github.com/open-feature/flagd/core/pkg/evaluator"
 	"github.com/open-feature/flagd/core/pkg/logger"
 	"github.com/open-feature/flagd/core/pkg/service"
+	"github.com/open-feature/flagd/core/pkg/snapshot"
+	"github.com/open-feature/flagd/core/pkg/store"
 	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/open-feature/flagd/core/pkg/telemetry"
+	"golang.org/x/exp/maps"
 	"golang.org/x/sync/errgroup"
 )
 
 type Runtime struct {
 	Evaluator     evaluator.IEvaluator
 	Logger        *logger.Logger
+	Metrics       *telemetry.MetricsRecorder
 	Service       service.IFlagEvaluationService
 	ServiceConfig service.Configuration
 	SyncImpl      []sync.ISync
+	// Sources holds the configuration of each SyncImpl, in the same order
+	Sources []sync.SourceConfig
+	// Store is the flag store of the Evaluator
+	Store *store.Flags
+	// Snapshots, if set, persist the flag configuration of each source, which is loaded on startup
+	Snapshots *snapshot.Dir
 
-	mu msync.Mutex
+	mu         msync.Mutex
+	rejections map[string]service.SyncRejection
+	lastSyncs  map[string]time.Time
+	// flagsChanged wakes up the watch of scheduled transitions whenever flag configurations are applied
+	flagsChanged chan struct{}
 }
 
 //nolint:funlen
@@ -41,6 +57,11 @@ func (r *Runtime) Start() error {
 	defer cancel()
 	g, gCtx := errgroup.WithContext(ctx)
 	dataSync := make(chan sync.DataSync, len(r.SyncImpl))
+	r.flagsChanged = make(chan struct{}, 1)
+	g.Go(func() error {
+		r.watchSchedules(gCtx)
+		return nil
+	})
 	// Initialize DataSync channel watcher
 	g.Go(func() error {
 		for {
@@ -68,6 +89,7 @@ func (r *Runtime) Start() error {
 			}
 		}
 	})
+	cached := r.loadSnapshots()
 	// Init sync providers
 	for _, s := range r.SyncImpl {
 		if err := s.Init(gCtx); err != nil {
@@ -75,13 +97,11 @@ func (r *Runtime) Start() error {
 		}
 	}
 	// Start sync provider
-	for _, s := range r.SyncImpl {
+	for i, s := range r.SyncImpl {
 		p := s
+		source := r.sourceURI(i)
 		g.Go(func() error {
-			if err := p.Sync(gCtx, dataSync); err != nil {
-				return fmt.Errorf("sync provider returned error: %w", err)
-			}
-			return nil
+			return r.sync(gCtx, p, source, cached[source], dataSync)
 		})
 	}
 
@@ -94,6 +114,8 @@ func (r *Runtime) Start() error {
 	g.Go(func() error {
 		// Readiness probe rely on the runtime
 		r.ServiceConfig.ReadinessProbe = r.isReady
+		r.ServiceConfig.SyncRejections = r.syncRejections
+		r.ServiceConfig.SourceStatuses = r.sourceStatuses
 		if err := r.Service.Serve(gCtx, r.ServiceConfig); err != nil {
 			return fmt.Errorf("error returned from serving flag evaluation service: %w", err)
 		}
@@ -107,15 +129,51 @@ func (r *Runtime) Start() error {
 }
 
 func (r *Runtime) isReady() bool {
-	// if all providers can watch for flag changes, we are ready.
-	for _, p := range r.SyncImpl {
-		if !p.IsReady() {
+	// if all providers can watch for flag changes, we are ready. Sources loaded from a snapshot are ready as well.
+	for i, p := range r.SyncImpl {
+		if !p.IsReady() && !r.isSourceCached(r.sourceURI(i)) {
 			return false
 		}
 	}
 	return true
 }
 
+// syncRejections returns the sources whose last flag configuration was rejected, along with the rejection reason
+func (r *Runtime) syncRejections() map[string]service.SyncRejection {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return maps.Clone(r.rejections)
+}
+
+// sourceStatuses returns the status of the configured sources, in configuration order
+func (r *Runtime) sourceStatuses() []service.SourceStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make([]service.SourceStatus, 0, len(r.Sources))
+	for i, source := range r.Sources {
+		status := service.SourceStatus{
+			Source:    source.URI,
+			Provider:  source.Provider,
+			Selector:  source.Selector,
+			Namespace: source.Namespace,
+		}
+		if i < len(r.SyncImpl) {
+			status.Ready = r.SyncImpl[i].IsReady()
+		}
+		if lastSync, ok := r.lastSyncs[source.URI]; ok {
+			status.LastSync = &lastSync
+		}
+		if r.Store != nil {
+			status.Metadata = r.Store.SyncMetadata(source.URI)
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
 // updateWithNotify helps to update state and notify listeners
 func (r *Runtime) updateWithNotify(payload sync.DataSync) bool {
 	r.mu.Lock()
@@ -123,9 +181,30 @@ func (r *Runtime) updateWithNotify(payload sync.DataSync) bool {
 
 	notifications, resyncRequired, err := r.Evaluator.SetState(payload)
 	if err != nil {
-		r.Logger.Error(err.Error())
+		// the store keeps the last known good state of the source
+		r.Logger.Error(fmt.Sprintf("rejected flag configuration from source %s: %s", payload.Source, err.Error()))
+		if r.rejections == nil {
+			r.rejections = map[string]service.SyncRejection{}
+		}
+		r.rejections[payload.Source] = service.SyncRejection{
+			Reason: err.Error(),
+			Time:   time.Now(),
+		}
+		if r.Metrics != nil {
+			r.Metrics.SyncRejection(context.Background(), payload.Source)
+		}
 		return false
 	}
+	delete(r.rejections, payload.Source)
+	r.persist(payload)
+	if r.lastSyncs == nil {
+		r.lastSyncs = map[string]time.Time{}
+	}
+	r.lastSyncs[payload.Source] = time.Now()
+	select {
+	case r.flagsChanged <- struct{}{}:
+	default:
+	}
 
 	r.Service.Notify(service.Notification{
 		Type: service.ConfigurationChange,
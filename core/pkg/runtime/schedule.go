@@ -0,0 +1,64 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/service"
+	"golang.org/x/exp/maps"
+)
+
+// watchSchedules notifies a configuration change of the flags whose scheduled transitions fire, so that providers
+// caching flag evaluations refresh them, until the context is done
+func (r *Runtime) watchSchedules(ctx context.Context) {
+	if r.Store == nil {
+		return
+	}
+
+	last := time.Now()
+	for {
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if next, ok := r.Store.NextTransition(last); ok {
+			timer = time.NewTimer(time.Until(next))
+			fire = timer.C
+		}
+
+		select {
+		case <-fire:
+			now := time.Now()
+			r.notifyTransitions(last, now)
+			last = now
+		case <-r.flagsChanged:
+			// flag configurations changed, the next transition is looked up again
+		case <-ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// notifyTransitions notifies a configuration change of the flags with a scheduled transition in the time range
+// (from, to], one notification being sent per namespace like for the changes of flag configurations
+func (r *Runtime) notifyTransitions(from time.Time, to time.Time) {
+	transitions := r.Store.Transitions(from, to)
+	namespaces := maps.Keys(transitions)
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		notifications := transitions[namespace]
+		r.Logger.Debug(fmt.Sprintf("scheduled transitions of %d flags fired", len(notifications)))
+		r.Service.Notify(service.Notification{
+			Type: service.ConfigurationChange,
+			Data: map[string]interface{}{
+				"flags": notifications,
+			},
+		})
+	}
+}
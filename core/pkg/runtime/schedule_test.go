@@ -0,0 +1,93 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/service"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchSchedules(t *testing.T) {
+	lg := logger.NewLogger(nil, false)
+	flags := store.NewFlags()
+	activate := time.Now().Add(50 * time.Millisecond)
+	flags.Merge(lg, "A", map[string]model.Flag{"a": {Schedule: &model.Schedule{Activate: &activate}}})
+
+	svc := &notifyingService{notifications: make(chan service.Notification, 10)}
+	r := &Runtime{Logger: lg, Service: svc, Store: flags, flagsChanged: make(chan struct{}, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.watchSchedules(ctx)
+		close(done)
+	}()
+
+	requireTransition := func(key string) {
+		t.Helper()
+		select {
+		case notification := <-svc.notifications:
+			require.Equal(t, service.ConfigurationChange, notification.Type)
+			require.Contains(t, notification.Data["flags"], key)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the scheduled transition of %s", key)
+		}
+	}
+	requireTransition("a")
+
+	// transitions of flag configurations applied later are watched as well
+	deactivate := time.Now().Add(50 * time.Millisecond)
+	flags.Merge(lg, "A", map[string]model.Flag{"b": {Schedule: &model.Schedule{Deactivate: &deactivate}}})
+	r.flagsChanged <- struct{}{}
+	requireTransition("b")
+
+	cancel()
+	<-done
+}
+
+func TestNotifyTransitions_Namespaces(t *testing.T) {
+	lg := logger.NewLogger(nil, false)
+	flags := store.NewFlags()
+	flags.SourceMetadata["B"] = store.SourceDetails{Source: "B", Namespace: "team"}
+	activate := time.Now()
+	flags.Merge(lg, "A", map[string]model.Flag{"a": {Schedule: &model.Schedule{Activate: &activate}}})
+	flags.Namespace("team").Merge(lg, "B", map[string]model.Flag{"a": {Schedule: &model.Schedule{Activate: &activate}}})
+
+	svc := &notifyingService{notifications: make(chan service.Notification, 10)}
+	r := &Runtime{Logger: lg, Service: svc, Store: flags}
+	r.notifyTransitions(activate.Add(-time.Second), activate)
+
+	// the flags of each namespace are notified apart, as they share their key
+	require.Len(t, svc.notifications, 2)
+	for _, namespace := range []string{"", "team"} {
+		notification := <-svc.notifications
+		require.Equal(t, service.ConfigurationChange, notification.Type)
+		changes, ok := notification.Data["flags"].(map[string]interface{})
+		require.True(t, ok)
+		require.Len(t, changes, 1)
+		if namespace == "" {
+			require.NotContains(t, changes["a"], "namespace")
+		} else {
+			require.Equal(t, namespace, changes["a"].(map[string]interface{})["namespace"])
+		}
+	}
+}
+
+// notifyingService records the notifications it is sent
+type notifyingService struct {
+	notifications chan service.Notification
+}
+
+func (s *notifyingService) Serve(_ context.Context, _ service.Configuration) error {
+	return nil
+}
+
+func (s *notifyingService) Notify(n service.Notification) {
+	s.notifications <- n
+}
+
+func (s *notifyingService) Shutdown() {}
@@ -0,0 +1,112 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/sync"
+)
+
+// retry intervals of failed sync providers, variables so that tests can shorten them
+var (
+	syncRetryInterval    = 5 * time.Second
+	syncMaxRetryInterval = time.Minute
+)
+
+// sourceURI returns the URI of the i-th sync provider, empty if its configuration is unknown
+func (r *Runtime) sourceURI(i int) string {
+	if i < len(r.Sources) {
+		return r.Sources[i].URI
+	}
+	return ""
+}
+
+// isSourceCached checks whether the flags of the source are served from its snapshot
+func (r *Runtime) isSourceCached(source string) bool {
+	return r.Store != nil && r.Store.IsSourceCached(source)
+}
+
+// loadSnapshots applies the snapshot of each source, if any, before sync providers start. The flags of these sources
+// are marked as cached until their source is synced live. The sources loaded from a snapshot are returned.
+func (r *Runtime) loadSnapshots() map[string]bool {
+	cached := map[string]bool{}
+	if r.Snapshots == nil {
+		return cached
+	}
+
+	for _, source := range r.Sources {
+		payload, ok, err := r.Snapshots.Load(source.URI)
+		if err != nil {
+			r.Logger.Warn(fmt.Sprintf("unable to load snapshot of source %s: %v", source.URI, err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		_, _, err = r.Evaluator.SetState(payload)
+		if err != nil {
+			r.Logger.Warn(fmt.Sprintf("rejected snapshot of source %s: %v", source.URI, err))
+			continue
+		}
+		if r.Store != nil {
+			r.Store.SetSourceCached(source.URI, true)
+		}
+		cached[source.URI] = true
+		r.Logger.Info(fmt.Sprintf("loaded flags of source %s from snapshot", source.URI))
+	}
+
+	return cached
+}
+
+// sync runs the sync provider of the source. Providers of sources loaded from a snapshot are restarted with an
+// exponential backoff when they fail, while the last known flags of the source keep being served. Failures of other
+// providers stop the runtime.
+func (r *Runtime) sync(
+	ctx context.Context, p sync.ISync, source string, cached bool, dataSync chan<- sync.DataSync,
+) error {
+	retryInterval := syncRetryInterval
+	for {
+		err := p.Sync(ctx, dataSync)
+		if err == nil {
+			return nil
+		}
+		if !cached || ctx.Err() != nil {
+			return fmt.Errorf("sync provider returned error: %w", err)
+		}
+
+		// providers release their resources, such as file watchers, once Sync returns, so that they are initialized
+		// again before being restarted
+		for {
+			r.Logger.Warn(fmt.Sprintf("sync provider of source %s returned error, serving its last known flags "+
+				"and retrying in %s: %v", source, retryInterval, err))
+			select {
+			case <-time.After(retryInterval):
+			case <-ctx.Done():
+				return nil
+			}
+			retryInterval = min(2*retryInterval, syncMaxRetryInterval)
+
+			if err = p.Init(ctx); err == nil {
+				break
+			}
+		}
+	}
+}
+
+// persist marks the flags of the source as synced live and saves its applied flag configuration. Overrides are not
+// persisted.
+func (r *Runtime) persist(payload sync.DataSync) {
+	if r.Store != nil {
+		r.Store.SetSourceCached(payload.Source, false)
+	}
+	if r.Snapshots == nil || model.IsOverrideSource(payload.Source) {
+		return
+	}
+
+	if err := r.Snapshots.Save(payload); err != nil {
+		r.Logger.Warn(fmt.Sprintf("unable to save snapshot of source %s: %v", payload.Source, err))
+	}
+}
@@ -0,0 +1,164 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/snapshot"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSnapshots(t *testing.T) {
+	lg := logger.NewLogger(nil, false)
+	snapshots, err := snapshot.NewDir(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, snapshots.Save(sync.DataSync{
+		FlagData: loadFlags,
+		Source:   "A",
+		Type:     sync.ALL,
+		Metadata: map[string]interface{}{"commit": "abc"},
+	}))
+	require.NoError(t, snapshots.Save(sync.DataSync{FlagData: `{"flags":"invalid"}`, Source: "B", Type: sync.ALL}))
+
+	flags := store.NewFlags()
+	r := &Runtime{
+		Evaluator: evaluator.NewJSON(lg, flags),
+		Logger:    lg,
+		Sources:   []sync.SourceConfig{{URI: "A"}, {URI: "B"}, {URI: "C"}},
+		Store:     flags,
+		Snapshots: snapshots,
+	}
+
+	// rejected and missing snapshots are skipped
+	require.Equal(t, map[string]bool{"A": true}, r.loadSnapshots())
+	require.True(t, flags.IsSourceCached("A"))
+	require.False(t, flags.IsSourceCached("B"))
+	_, ok := flags.Get("myBoolFlag")
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{"commit": "abc"}, flags.SyncMetadata("A"))
+
+	// flags synced live are no longer cached, and their flag configuration is saved
+	r.persist(sync.DataSync{FlagData: `{"flags":{}}`, Source: "A", Type: sync.ALL})
+	require.False(t, flags.IsSourceCached("A"))
+	saved, ok, err := snapshots.Load("A")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, `{"flags":{}}`, saved.FlagData)
+}
+
+func TestLoadSnapshots_disabled(t *testing.T) {
+	r := &Runtime{Sources: []sync.SourceConfig{{URI: "A"}}}
+	require.Empty(t, r.loadSnapshots())
+}
+
+func TestSync_Retry(t *testing.T) {
+	interval, maxInterval := syncRetryInterval, syncMaxRetryInterval
+	syncRetryInterval, syncMaxRetryInterval = time.Millisecond, 4*time.Millisecond
+	t.Cleanup(func() {
+		syncRetryInterval, syncMaxRetryInterval = interval, maxInterval
+	})
+
+	errSync := errors.New("sync failed")
+	errInit := errors.New("init failed")
+
+	t.Run("providers of cached sources are initialized again and restarted", func(t *testing.T) {
+		p := &failingSync{syncErrors: []error{errSync, errSync}, initErrors: []error{errInit}}
+		r := &Runtime{Logger: logger.NewLogger(nil, false)}
+
+		require.NoError(t, r.sync(context.Background(), p, "A", true, make(chan sync.DataSync)))
+		require.Equal(t, 3, p.syncs)
+		require.Equal(t, 3, p.inits, "failed initializations are retried before restarting the provider")
+	})
+
+	t.Run("failures of providers of other sources stop the runtime", func(t *testing.T) {
+		p := &failingSync{syncErrors: []error{errSync}}
+		r := &Runtime{Logger: logger.NewLogger(nil, false)}
+
+		require.ErrorIs(t, r.sync(context.Background(), p, "A", false, make(chan sync.DataSync)), errSync)
+		require.Equal(t, 1, p.syncs)
+		require.Zero(t, p.inits)
+	})
+
+	t.Run("retries stop with the runtime", func(t *testing.T) {
+		p := &failingSync{syncErrors: []error{errSync}, synced: make(chan struct{}, 1)}
+		r := &Runtime{Logger: logger.NewLogger(nil, false)}
+		syncRetryInterval = time.Hour
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- r.sync(ctx, p, "A", true, make(chan sync.DataSync))
+		}()
+		<-p.synced
+		cancel()
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the sync to stop")
+		}
+	})
+}
+
+func TestIsReady(t *testing.T) {
+	flags := store.NewFlags()
+	r := &Runtime{
+		SyncImpl: []sync.ISync{&failingSync{ready: true}, &failingSync{}},
+		Sources:  []sync.SourceConfig{{URI: "A"}, {URI: "B"}},
+		Store:    flags,
+	}
+	require.False(t, r.isReady())
+
+	// sources loaded from a snapshot are ready until they are synced live
+	flags.SetSourceCached("B", true)
+	require.True(t, r.isReady())
+
+	flags.SetSourceCached("B", false)
+	require.False(t, r.isReady())
+}
+
+// failingSync is a sync provider whose Init and Sync calls return the given errors in turn, then succeed
+type failingSync struct {
+	sync.ISync
+	ready      bool
+	syncErrors []error
+	initErrors []error
+	syncs      int
+	inits      int
+	// synced, if set, is signaled by each Sync call
+	synced chan struct{}
+}
+
+func (s *failingSync) Init(_ context.Context) error {
+	s.inits++
+	return nextError(&s.initErrors)
+}
+
+func (s *failingSync) Sync(_ context.Context, _ chan<- sync.DataSync) error {
+	s.syncs++
+	if s.synced != nil {
+		s.synced <- struct{}{}
+	}
+	return nextError(&s.syncErrors)
+}
+
+func (s *failingSync) IsReady() bool {
+	return s.ready
+}
+
+func nextError(errs *[]error) error {
+	if len(*errs) == 0 {
+		return nil
+	}
+	err := (*errs)[0]
+	*errs = (*errs)[1:]
+	return err
+}
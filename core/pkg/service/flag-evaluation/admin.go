@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/service"
+	adminv1 "github.com/open-feature/flagd/core/pkg/service/admin/v1"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	adminFlagsURL   = "/admin/flags"
+	adminSourcesURL = "/admin/sources"
+
+	adminSourceParam    = "source"
+	adminSelectorParam  = "selector"
+	adminNamespaceParam = "namespace"
+)
+
+// adminFilter narrows flags and sources down to a source, a selector and/or a namespace, empty fields match
+// everything. Flags are always listed from a single namespace though, the default namespace if none is given.
+type adminFilter struct {
+	Source    string
+	Selector  string
+	Namespace string
+}
+
+func (f adminFilter) matches(source string, selector string) bool {
+	return (f.Source == "" || f.Source == source) && (f.Selector == "" || f.Selector == selector)
+}
+
+// adminFlag is the admin API representation of a stored flag
+type adminFlag struct {
+	Key            string          `json:"key"`
+	Source         string          `json:"source"`
+	Selector       string          `json:"selector,omitempty"`
+	State          string          `json:"state"`
+	DefaultVariant string          `json:"defaultVariant"`
+	Variants       map[string]any  `json:"variants"`
+	Targeting      json.RawMessage `json:"targeting,omitempty"`
+	Metadata       map[string]any  `json:"metadata,omitempty"`
+	Revision       uint64          `json:"revision"`
+	UpdatedAt      *time.Time      `json:"updatedAt,omitempty"`
+	Override       bool            `json:"override,omitempty"`
+}
+
+type adminFlagsResponse struct {
+	Namespace string      `json:"namespace,omitempty"`
+	Revision  uint64      `json:"revision"`
+	Flags     []adminFlag `json:"flags"`
+}
+
+type adminSourcesResponse struct {
+	Sources []service.SourceStatus `json:"sources"`
+}
+
+// adminService is the read-only admin API of the management port, inspecting the live flag store and the
+// configured flag sources
+type adminService struct {
+	logger  *logger.Logger
+	store   *store.Flags
+	sources service.SourceStatusProbe
+}
+
+func newAdminService(logger *logger.Logger, store *store.Flags, sources service.SourceStatusProbe) *adminService {
+	return &adminService{
+		logger:  logger,
+		store:   store,
+		sources: sources,
+	}
+}
+
+// register mounts the HTTP endpoints on the mux and the gRPC service on the server
+func (a *adminService) register(mux *http.ServeMux, server *grpc.Server) {
+	mux.HandleFunc(adminFlagsURL, a.serveHTTP(func(filter adminFilter) interface{} {
+		return a.listFlags(filter)
+	}))
+	mux.HandleFunc(adminSourcesURL, a.serveHTTP(func(filter adminFilter) interface{} {
+		return a.listSources(filter)
+	}))
+	adminv1.RegisterAdminServiceServer(server, &adminGRPCService{admin: a})
+}
+
+// listFlags returns the stored flags of the namespace of the filter matching the filter, sorted by key
+func (a *adminService) listFlags(filter adminFilter) adminFlagsResponse {
+	response := adminFlagsResponse{Namespace: filter.Namespace, Flags: []adminFlag{}}
+	if a.store == nil {
+		return response
+	}
+	flags, ok := a.store.LookupNamespace(filter.Namespace)
+	if !ok {
+		return response
+	}
+
+	response.Revision = flags.Revision()
+	revisions := flags.Revisions()
+	for key, flag := range flags.GetAll() {
+		selector := a.store.SelectorForFlag(flag)
+		if !filter.matches(flag.Source, selector) {
+			continue
+		}
+
+		entry := adminFlag{
+			Key:            key,
+			Source:         flag.Source,
+			Selector:       selector,
+			State:          flag.State,
+			DefaultVariant: flag.DefaultVariant,
+			Variants:       flag.Variants,
+			Targeting:      flag.Targeting,
+			Metadata:       flag.Metadata,
+			Override:       model.IsOverrideSource(flag.Source),
+		}
+		if revision, ok := revisions[key]; ok {
+			entry.Revision = revision.Revision
+			entry.UpdatedAt = &revision.UpdatedAt
+		}
+		response.Flags = append(response.Flags, entry)
+	}
+	sort.Slice(response.Flags, func(i, j int) bool {
+		return response.Flags[i].Key < response.Flags[j].Key
+	})
+
+	return response
+}
+
+// listSources returns the configured flag sources matching the filter, in configuration order
+func (a *adminService) listSources(filter adminFilter) adminSourcesResponse {
+	response := adminSourcesResponse{Sources: []service.SourceStatus{}}
+	if a.sources == nil {
+		return response
+	}
+
+	for _, status := range a.sources() {
+		if filter.matches(status.Source, status.Selector) &&
+			(filter.Namespace == "" || filter.Namespace == status.Namespace) {
+			response.Sources = append(response.Sources, status)
+		}
+	}
+
+	return response
+}
+
+// serveHTTP serves GET requests of the admin API, filters are read from the source, selector and namespace query
+// parameters
+func (a *adminService) serveHTTP(list func(adminFilter) interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		filter := adminFilter{
+			Source:    r.URL.Query().Get(adminSourceParam),
+			Selector:  r.URL.Query().Get(adminSelectorParam),
+			Namespace: r.URL.Query().Get(adminNamespaceParam),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(list(filter)); err != nil {
+			a.logger.Error(fmt.Sprintf("error writing admin response: %v", err))
+		}
+	}
+}
+
+// adminGRPCService serves the admin API as the flagd.admin.v1.AdminService gRPC service
+type adminGRPCService struct {
+	adminv1.UnimplementedAdminServiceServer
+	admin *adminService
+}
+
+func (s *adminGRPCService) ListFlags(
+	_ context.Context, req *adminv1.ListFlagsRequest,
+) (*adminv1.ListFlagsResponse, error) {
+	listed := s.admin.listFlags(adminFilter{
+		Source:    req.GetSource(),
+		Selector:  req.GetSelector(),
+		Namespace: req.GetNamespace(),
+	})
+
+	response := &adminv1.ListFlagsResponse{
+		Namespace: listed.Namespace,
+		Revision:  listed.Revision,
+		Flags:     make([]*adminv1.Flag, 0, len(listed.Flags)),
+	}
+	for _, flag := range listed.Flags {
+		message, err := toFlagMessage(flag)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error converting flag %s: %v", flag.Key, err)
+		}
+		response.Flags = append(response.Flags, message)
+	}
+
+	return response, nil
+}
+
+func (s *adminGRPCService) ListSources(
+	_ context.Context, req *adminv1.ListSourcesRequest,
+) (*adminv1.ListSourcesResponse, error) {
+	listed := s.admin.listSources(adminFilter{
+		Source:    req.GetSource(),
+		Selector:  req.GetSelector(),
+		Namespace: req.GetNamespace(),
+	})
+
+	response := &adminv1.ListSourcesResponse{Sources: make([]*adminv1.Source, 0, len(listed.Sources))}
+	for _, source := range listed.Sources {
+		metadata, err := toStruct(source.Metadata)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error converting metadata of source %s: %v", source.Source, err)
+		}
+
+		message := &adminv1.Source{
+			Source:    source.Source,
+			Provider:  source.Provider,
+			Selector:  source.Selector,
+			Namespace: source.Namespace,
+			Ready:     source.Ready,
+			Metadata:  metadata,
+		}
+		if source.LastSync != nil {
+			message.LastSync = timestamppb.New(*source.LastSync)
+		}
+		response.Sources = append(response.Sources, message)
+	}
+
+	return response, nil
+}
+
+func toFlagMessage(flag adminFlag) (*adminv1.Flag, error) {
+	variants, err := toStruct(flag.Variants)
+	if err != nil {
+		return nil, fmt.Errorf("variants: %w", err)
+	}
+	metadata, err := toStruct(flag.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("metadata: %w", err)
+	}
+
+	message := &adminv1.Flag{
+		Key:            flag.Key,
+		Source:         flag.Source,
+		Selector:       flag.Selector,
+		State:          flag.State,
+		DefaultVariant: flag.DefaultVariant,
+		Variants:       variants,
+		Metadata:       metadata,
+		Revision:       flag.Revision,
+		Override:       flag.Override,
+	}
+	if len(flag.Targeting) > 0 {
+		message.Targeting = &structpb.Value{}
+		if err := message.Targeting.UnmarshalJSON(flag.Targeting); err != nil {
+			return nil, fmt.Errorf("targeting: %w", err)
+		}
+	}
+	if flag.UpdatedAt != nil {
+		message.UpdatedAt = timestamppb.New(*flag.UpdatedAt)
+	}
+
+	return message, nil
+}
+
+// toStruct converts a JSON object to a struct
+func toStruct(m map[string]any) (*structpb.Struct, error) {
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil, fmt.Errorf("error converting to struct: %w", err)
+	}
+	return s, nil
+}
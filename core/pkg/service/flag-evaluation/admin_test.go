@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	iservice "github.com/open-feature/flagd/core/pkg/service"
+	adminv1 "github.com/open-feature/flagd/core/pkg/service/admin/v1"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestAdminService(t *testing.T) (*adminService, time.Time) {
+	t.Helper()
+
+	log := logger.NewLogger(nil, false)
+	flags := store.NewFlags()
+	flags.FlagSources = []string{"A", "B"}
+	flags.SourceMetadata["A"] = store.SourceDetails{Source: "A"}
+	flags.SourceMetadata["B"] = store.SourceDetails{Source: "B", Selector: "app=b"}
+	flags.Merge(log, "A", map[string]model.Flag{
+		"a": {State: "ENABLED", DefaultVariant: "on", Variants: map[string]any{"on": true}},
+	})
+	flags.Merge(log, "B", map[string]model.Flag{
+		"b": {State: "DISABLED", DefaultVariant: "off", Variants: map[string]any{"off": false}},
+	})
+
+	lastSync := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sources := func() []iservice.SourceStatus {
+		return []iservice.SourceStatus{
+			{Source: "A", Provider: "file", Ready: true, LastSync: &lastSync},
+			{Source: "B", Provider: "grpc", Selector: "app=b"},
+		}
+	}
+
+	return newAdminService(log, flags, sources), lastSync
+}
+
+func TestAdmin_listFlags(t *testing.T) {
+	admin, _ := newTestAdminService(t)
+
+	response := admin.listFlags(adminFilter{})
+	require.Equal(t, uint64(2), response.Revision)
+	require.Len(t, response.Flags, 2)
+	require.Equal(t, "a", response.Flags[0].Key)
+	require.Equal(t, "A", response.Flags[0].Source)
+	require.Equal(t, uint64(1), response.Flags[0].Revision)
+	require.NotNil(t, response.Flags[0].UpdatedAt)
+	require.Equal(t, "b", response.Flags[1].Key)
+	require.Equal(t, "app=b", response.Flags[1].Selector)
+	require.Equal(t, "DISABLED", response.Flags[1].State)
+	require.Equal(t, uint64(2), response.Flags[1].Revision)
+
+	response = admin.listFlags(adminFilter{Source: "A"})
+	require.Len(t, response.Flags, 1)
+	require.Equal(t, "a", response.Flags[0].Key)
+
+	response = admin.listFlags(adminFilter{Selector: "app=b"})
+	require.Len(t, response.Flags, 1)
+	require.Equal(t, "b", response.Flags[0].Key)
+
+	response = admin.listFlags(adminFilter{Source: "A", Selector: "app=b"})
+	require.Empty(t, response.Flags)
+}
+
+func TestAdmin_namespace(t *testing.T) {
+	admin, _ := newTestAdminService(t)
+	log := logger.NewLogger(nil, false)
+	admin.store.SourceMetadata["C"] = store.SourceDetails{Source: "C", Namespace: "team"}
+	team := admin.store.Namespace("team")
+	team.FlagSources = []string{"C"}
+	team.Merge(log, "C", map[string]model.Flag{
+		"a": {State: "ENABLED", DefaultVariant: "off", Variants: map[string]any{"off": false}},
+	})
+	admin.sources = func() []iservice.SourceStatus {
+		return []iservice.SourceStatus{{Source: "A", Provider: "file"}, {Source: "C", Provider: "file", Namespace: "team"}}
+	}
+
+	// flags are listed from a single namespace, the default namespace if none is given
+	response := admin.listFlags(adminFilter{Namespace: "team"})
+	require.Equal(t, "team", response.Namespace)
+	require.Equal(t, uint64(1), response.Revision)
+	require.Len(t, response.Flags, 1)
+	require.Equal(t, "C", response.Flags[0].Source)
+	require.Len(t, admin.listFlags(adminFilter{}).Flags, 2)
+	require.Empty(t, admin.listFlags(adminFilter{Namespace: "other"}).Flags)
+
+	require.Len(t, admin.listSources(adminFilter{}).Sources, 2)
+	sources := admin.listSources(adminFilter{Namespace: "team"}).Sources
+	require.Len(t, sources, 1)
+	require.Equal(t, "C", sources[0].Source)
+}
+
+func TestAdmin_http(t *testing.T) {
+	admin, lastSync := newTestAdminService(t)
+	mux := http.NewServeMux()
+	admin.register(mux, grpc.NewServer())
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, adminFlagsURL+"?source=B", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var flags adminFlagsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flags))
+	require.Len(t, flags.Flags, 1)
+	require.Equal(t, "b", flags.Flags[0].Key)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, adminSourcesURL, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var sources adminSourcesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &sources))
+	require.Len(t, sources.Sources, 2)
+	require.True(t, sources.Sources[0].Ready)
+	require.True(t, lastSync.Equal(*sources.Sources[0].LastSync))
+	require.False(t, sources.Sources[1].Ready)
+	require.Nil(t, sources.Sources[1].LastSync)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, adminSourcesURL, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestAdmin_grpc(t *testing.T) {
+	admin, lastSync := newTestAdminService(t)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	admin.register(http.NewServeMux(), server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := adminv1.NewAdminServiceClient(conn)
+
+	flags, err := client.ListFlags(context.Background(), &adminv1.ListFlagsRequest{Selector: "app=b"})
+	require.NoError(t, err)
+	require.Len(t, flags.GetFlags(), 1)
+	flag := flags.GetFlags()[0]
+	require.Equal(t, "b", flag.GetKey())
+	require.Equal(t, "B", flag.GetSource())
+	require.Equal(t, "DISABLED", flag.GetState())
+	require.Equal(t, map[string]interface{}{"off": false}, flag.GetVariants().AsMap())
+
+	sources, err := client.ListSources(context.Background(), &adminv1.ListSourcesRequest{})
+	require.NoError(t, err)
+	require.Len(t, sources.GetSources(), 2)
+	require.True(t, sources.GetSources()[0].GetReady())
+	require.True(t, lastSync.Equal(sources.GetSources()[0].GetLastSync().AsTime()))
+	require.Nil(t, sources.GetSources()[1].GetLastSync())
+}
@@ -3,6 +3,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -13,6 +14,7 @@ import (
 
 	evaluationV1 "buf.build/gen/go/open-feature/flagd/connectrpc/go/flagd/evaluation/v1/evaluationv1connect"
 	schemaConnectV1 "buf.build/gen/go/open-feature/flagd/connectrpc/go/schema/v1/schemav1connect"
+	"connectrpc.com/connect"
 	"github.com/open-feature/flagd/core/pkg/evaluator"
 	"github.com/open-feature/flagd/core/pkg/logger"
 	"github.com/open-feature/flagd/core/pkg/service"
@@ -23,6 +25,7 @@ import (
 	"github.com/open-feature/flagd/core/pkg/telemetry"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"golang.org/x/sync/errgroup"
@@ -41,15 +44,23 @@ const (
 // bufSwitchHandler combines the handlers of the old and new evaluation schema and combines them into one
 // this way we support both the new and the (deprecated) old schemas until only the new schema is supported
 // NOTE: this will not be required anymore when it is time to work on https://github.com/open-feature/flagd/issues/1088
+// OFREP and Server-Sent Events requests are routed to their dedicated handlers.
 type bufSwitchHandler struct {
-	old http.Handler
-	new http.Handler
+	old    http.Handler
+	new    http.Handler
+	ofrep  http.Handler
+	events http.Handler
 }
 
 func (b bufSwitchHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
-	if strings.HasPrefix(request.URL.Path, flagdSchemaPrefix) {
+	switch {
+	case strings.HasPrefix(request.URL.Path, ofrepPrefix):
+		b.ofrep.ServeHTTP(writer, request)
+	case request.URL.Path == sseEventsURL:
+		b.events.ServeHTTP(writer, request)
+	case strings.HasPrefix(request.URL.Path, flagdSchemaPrefix):
 		b.new.ServeHTTP(writer, request)
-	} else {
+	default:
 		b.old.ServeHTTP(writer, request)
 	}
 }
@@ -69,19 +80,46 @@ type ConnectService struct {
 	readinessEnabled bool
 }
 
+// ConnectServiceOption configures a ConnectService
+type ConnectServiceOption func(s *ConnectService)
+
+// WithEventQueue bounds the number of notifications queued per event stream subscriber, the policy defines how
+// notifications are handled for subscribers which do not keep up
+func WithEventQueue(size int, policy OverflowPolicy) ConnectServiceOption {
+	return func(s *ConnectService) {
+		s.eventingConfiguration.queueSize = size
+		s.eventingConfiguration.overflowPolicy = policy
+	}
+}
+
 // NewConnectService creates a ConnectService with provided parameters
 func NewConnectService(
 	logger *logger.Logger, evaluator evaluator.IEvaluator, mRecorder *telemetry.MetricsRecorder,
+	opts ...ConnectServiceOption,
 ) *ConnectService {
-	return &ConnectService{
+	s := &ConnectService{
 		logger:  logger,
 		eval:    evaluator,
 		metrics: mRecorder,
 		eventingConfiguration: &eventingConfiguration{
-			subs: make(map[interface{}]chan service.Notification),
-			mu:   &sync.RWMutex{},
+			subs:           make(map[interface{}]*subscription),
+			mu:             &sync.RWMutex{},
+			queueSize:      DefaultEventQueueSize,
+			overflowPolicy: OverflowCoalesce,
+			metrics:        mRecorder,
 		},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if mRecorder != nil {
+		if err := mRecorder.ObserveEventQueueDepth(s.eventingConfiguration.queueDepths); err != nil {
+			logger.Error(err.Error())
+		}
+	}
+
+	return s
 }
 
 // Serve serves services with provided configuration options
@@ -158,7 +196,13 @@ func (s *ConnectService) setupServer(svcConf service.Configuration) (net.Listene
 		protojson.UnmarshalOptions{DiscardUnknown: true},
 	)
 
-	_, oldHandler := schemaConnectV1.NewServiceHandler(fes, append(svcConf.Options, marshalOpts)...)
+	handlerOpts := append([]connect.HandlerOption{}, svcConf.Options...)
+	handlerOpts = append(handlerOpts, marshalOpts,
+		connect.WithInterceptors(
+			explainInterceptor(), selectorInterceptor(), namespaceInterceptor(), metadataFilterInterceptor(),
+		))
+
+	_, oldHandler := schemaConnectV1.NewServiceHandler(fes, handlerOpts...)
 
 	// register handler for new flag evaluation schema
 
@@ -168,11 +212,19 @@ func (s *ConnectService) setupServer(svcConf service.Configuration) (net.Listene
 		s.metrics,
 	)
 
-	_, newHandler := evaluationV1.NewServiceHandler(newFes, append(svcConf.Options, marshalOpts)...)
+	_, newHandler := evaluationV1.NewServiceHandler(newFes, handlerOpts...)
+
+	// register handler for the OpenFeature Remote Evaluation Protocol
+	ofrepHandler := newOfrepHandler(s.logger.WithFields(zap.String("component", "ofrep")), s.eval, s.metrics)
+
+	// register handler for Server-Sent Events
+	eventsHandler := newSSEHandler(s.logger.WithFields(zap.String("component", "events")), s.eventingConfiguration)
 
 	bs := bufSwitchHandler{
-		old: oldHandler,
-		new: newHandler,
+		old:    oldHandler,
+		new:    newHandler,
+		ofrep:  ofrepHandler,
+		events: eventsHandler,
 	}
 
 	s.serverMtx.Lock()
@@ -257,6 +309,22 @@ func (s *ConnectService) startMetricsServer(svcConf service.Configuration) error
 		}
 	}))
 	mux.Handle("/metrics", promhttp.Handler())
+	newAdminService(s.logger.WithFields(zap.String("component", "admin")), svcConf.FlagStore, svcConf.SourceStatuses).
+		register(mux, grpc)
+	overrides := newOverridesHandler(
+		s.logger.WithFields(zap.String("component", "overrides")), svcConf.Overrides, svcConf.AdminToken)
+	mux.Handle(adminOverridesURL, overrides)
+	mux.Handle(adminOverridesURL+"/", overrides)
+	mux.Handle("/validation", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rejections := map[string]service.SyncRejection{}
+		if svcConf.SyncRejections != nil {
+			maps.Copy(rejections, svcConf.SyncRejections())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(rejections); err != nil {
+			s.logger.Error(fmt.Sprintf("error writing validation response: %v", err))
+		}
+	}))
 
 	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		// if this is 'application/grpc' and HTTP2, handle with gRPC, otherwise HTTP.
@@ -1,30 +1,98 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
 	"sync"
 
 	iservice "github.com/open-feature/flagd/core/pkg/service"
+	"github.com/open-feature/flagd/core/pkg/telemetry"
 )
 
-// eventingConfiguration is a wrapper for notification subscriptions
+// OverflowPolicy defines how notifications are handled once the queue of a subscriber is full
+type OverflowPolicy string
+
+const (
+	// OverflowDropOldest drops the oldest queued notification to make room for the new one
+	OverflowDropOldest OverflowPolicy = "drop-oldest"
+	// OverflowCoalesce merges the queued configuration_change notifications into a single one, falling back to
+	// dropping the oldest notification if this does not make room for the new one
+	OverflowCoalesce OverflowPolicy = "coalesce"
+	// OverflowDisconnect disconnects the subscriber by closing its notification channel
+	OverflowDisconnect OverflowPolicy = "disconnect"
+
+	// DefaultEventQueueSize is the default number of notifications queued per subscriber
+	DefaultEventQueueSize = 16
+)
+
+// errDisconnectedSubscriber is returned to subscribers disconnected by the OverflowDisconnect policy
+var errDisconnectedSubscriber = errors.New("event stream disconnected, notifications were not consumed fast enough")
+
+// ParseOverflowPolicy validates the given overflow policy
+func ParseOverflowPolicy(policy string) (OverflowPolicy, error) {
+	switch OverflowPolicy(policy) {
+	case OverflowDropOldest, OverflowCoalesce, OverflowDisconnect:
+		return OverflowPolicy(policy), nil
+	default:
+		return "", fmt.Errorf("unknown event overflow policy '%s', expected one of %s, %s or %s",
+			policy, OverflowDropOldest, OverflowCoalesce, OverflowDisconnect)
+	}
+}
+
+// subscription is the bounded notification queue of a subscriber
+type subscription struct {
+	// id tells the subscriber apart in metrics, subscription keys being requests
+	id            string
+	notifications chan iservice.Notification
+}
+
+// eventingConfiguration is a wrapper for notification subscriptions. Notifications are emitted without blocking,
+// the overflow policy applies to subscribers which do not keep up.
 type eventingConfiguration struct {
-	mu   *sync.RWMutex
-	subs map[interface{}]chan iservice.Notification
+	mu             *sync.RWMutex
+	subs           map[interface{}]*subscription
+	queueSize      int
+	overflowPolicy OverflowPolicy
+	metrics        *telemetry.MetricsRecorder
+	// lastID is the id of the last subscription
+	lastID uint64
 }
 
+// newNotificationChan creates a notification channel holding up to the configured queue size
+func (eventing *eventingConfiguration) newNotificationChan() chan iservice.Notification {
+	size := eventing.queueSize
+	if size < 1 {
+		size = DefaultEventQueueSize
+	}
+
+	return make(chan iservice.Notification, size)
+}
+
+// subscribe registers the notification channel of a subscriber, the channel is closed if the subscriber gets
+// disconnected by the OverflowDisconnect policy
 func (eventing *eventingConfiguration) subscribe(id interface{}, notifyChan chan iservice.Notification) {
 	eventing.mu.Lock()
 	defer eventing.mu.Unlock()
 
-	eventing.subs[id] = notifyChan
+	eventing.lastID++
+	eventing.subs[id] = &subscription{
+		id:            strconv.FormatUint(eventing.lastID, 10),
+		notifications: notifyChan,
+	}
 }
 
 func (eventing *eventingConfiguration) emitToAll(n iservice.Notification) {
-	eventing.mu.RLock()
-	defer eventing.mu.RUnlock()
+	eventing.mu.Lock()
+	defer eventing.mu.Unlock()
 
-	for _, send := range eventing.subs {
-		send <- n
+	for id, sub := range eventing.subs {
+		select {
+		case sub.notifications <- n:
+		default:
+			eventing.overflow(id, sub, n)
+		}
 	}
 }
 
@@ -34,3 +102,108 @@ func (eventing *eventingConfiguration) unSubscribe(id interface{}) {
 
 	delete(eventing.subs, id)
 }
+
+// queueDepths returns the number of notifications queued for each subscriber, by subscription id
+func (eventing *eventingConfiguration) queueDepths() map[string]int {
+	eventing.mu.RLock()
+	defer eventing.mu.RUnlock()
+
+	depths := make(map[string]int, len(eventing.subs))
+	for _, sub := range eventing.subs {
+		depths[sub.id] = len(sub.notifications)
+	}
+	return depths
+}
+
+// overflow applies the overflow policy to a subscriber whose queue is full. It must be called with the write lock
+// held, making the emitter the only writer of the queue.
+func (eventing *eventingConfiguration) overflow(id interface{}, sub *subscription, n iservice.Notification) {
+	dropped := 0
+
+	switch eventing.overflowPolicy {
+	case OverflowDisconnect:
+		dropped = len(sub.notifications) + 1
+		delete(eventing.subs, id)
+		close(sub.notifications)
+	case OverflowCoalesce:
+		queued := drain(sub.notifications)
+		pending := coalesce(append(queued, n))
+		for len(pending) > cap(sub.notifications) {
+			pending = pending[1:]
+		}
+		dropped = len(queued) + 1 - len(pending)
+		for _, notification := range pending {
+			sub.notifications <- notification
+		}
+	default:
+		select {
+		case <-sub.notifications:
+			dropped = 1
+		default:
+			// the subscriber caught up in the meantime
+		}
+		sub.notifications <- n
+	}
+
+	if eventing.metrics != nil && dropped > 0 {
+		eventing.metrics.EventsDropped(context.Background(), string(eventing.overflowPolicy), int64(dropped))
+	}
+}
+
+// drain empties the queue without blocking, returning the notifications in order
+func drain(notifications chan iservice.Notification) []iservice.Notification {
+	var queued []iservice.Notification
+	for {
+		select {
+		case n, ok := <-notifications:
+			if !ok {
+				return queued
+			}
+			queued = append(queued, n)
+		default:
+			return queued
+		}
+	}
+}
+
+// coalesce merges the configuration_change notifications of each namespace into the first one of the namespace, the
+// latest change of a flag wins. Flags of different namespaces sharing a key are different flags, the namespace of a
+// change is therefore read from its payload. The order of other notifications is kept.
+func coalesce(notifications []iservice.Notification) []iservice.Notification {
+	coalesced := make([]iservice.Notification, 0, len(notifications))
+	changedFlags := map[string]map[string]interface{}{}
+
+	for _, n := range notifications {
+		if n.Type != iservice.ConfigurationChange {
+			coalesced = append(coalesced, n)
+			continue
+		}
+
+		flags, _ := n.Data["flags"].(map[string]interface{})
+		for key, change := range flags {
+			namespace := changeNamespace(change)
+			merged, ok := changedFlags[namespace]
+			if !ok {
+				merged = map[string]interface{}{}
+				changedFlags[namespace] = merged
+				coalesced = append(coalesced, iservice.Notification{
+					Type: iservice.ConfigurationChange,
+					Data: map[string]interface{}{
+						"flags": merged,
+					},
+				})
+			}
+			merged[key] = change
+		}
+	}
+
+	return coalesced
+}
+
+// changeNamespace returns the namespace of the flag change of a configuration_change notification, empty for the
+// default namespace
+func changeNamespace(change interface{}) string {
+	details, _ := change.(map[string]interface{})
+	namespace, _ := details["namespace"].(string)
+	return namespace
+}
@@ -11,7 +11,7 @@ import (
 func TestSubscribe(t *testing.T) {
 	// given
 	eventing := &eventingConfiguration{
-		subs: make(map[interface{}]chan iservice.Notification),
+		subs: make(map[interface{}]*subscription),
 		mu:   &sync.RWMutex{},
 	}
 
@@ -26,14 +26,14 @@ func TestSubscribe(t *testing.T) {
 	eventing.subscribe(idB, chanB)
 
 	// then
-	require.Equal(t, chanA, eventing.subs[idA], "incorrect subscription association")
-	require.Equal(t, chanB, eventing.subs[idB], "incorrect subscription association")
+	require.Equal(t, chanA, eventing.subs[idA].notifications, "incorrect subscription association")
+	require.Equal(t, chanB, eventing.subs[idB].notifications, "incorrect subscription association")
 }
 
 func TestUnsubscribe(t *testing.T) {
 	// given
 	eventing := &eventingConfiguration{
-		subs: make(map[interface{}]chan iservice.Notification),
+		subs: make(map[interface{}]*subscription),
 		mu:   &sync.RWMutex{},
 	}
 
@@ -51,5 +51,154 @@ func TestUnsubscribe(t *testing.T) {
 	// then
 	require.Empty(t, eventing.subs[idA],
 		"expected subscription cleared, but value present: %v", eventing.subs[idA])
-	require.Equal(t, chanB, eventing.subs[idB], "incorrect subscription association")
+	require.Equal(t, chanB, eventing.subs[idB].notifications, "incorrect subscription association")
+}
+
+func configurationChange(flags ...string) iservice.Notification {
+	changes := map[string]interface{}{}
+	for _, flag := range flags {
+		changes[flag] = map[string]interface{}{"type": "update", "source": "file"}
+	}
+	return iservice.Notification{
+		Type: iservice.ConfigurationChange,
+		Data: map[string]interface{}{"flags": changes},
+	}
+}
+
+func TestEmitToAll_overflow(t *testing.T) {
+	ready := iservice.Notification{Type: iservice.ProviderReady}
+
+	tests := map[string]struct {
+		policy           OverflowPolicy
+		queued           []iservice.Notification
+		notification     iservice.Notification
+		want             []iservice.Notification
+		wantDisconnected bool
+	}{
+		"drop oldest": {
+			policy:       OverflowDropOldest,
+			queued:       []iservice.Notification{ready, configurationChange("a")},
+			notification: configurationChange("b"),
+			want:         []iservice.Notification{configurationChange("a"), configurationChange("b")},
+		},
+		"coalesce configuration changes": {
+			policy:       OverflowCoalesce,
+			queued:       []iservice.Notification{ready, configurationChange("a")},
+			notification: configurationChange("b"),
+			want:         []iservice.Notification{ready, configurationChange("a", "b")},
+		},
+		"coalesce without configuration changes to merge": {
+			policy:       OverflowCoalesce,
+			queued:       []iservice.Notification{ready, ready},
+			notification: configurationChange("a"),
+			want:         []iservice.Notification{ready, configurationChange("a")},
+		},
+		"disconnect": {
+			policy:           OverflowDisconnect,
+			queued:           []iservice.Notification{ready, configurationChange("a")},
+			notification:     configurationChange("b"),
+			wantDisconnected: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			metrics, _ := getMetricReader()
+			eventing := &eventingConfiguration{
+				subs:           make(map[interface{}]*subscription),
+				mu:             &sync.RWMutex{},
+				queueSize:      len(tt.queued),
+				overflowPolicy: tt.policy,
+				metrics:        metrics,
+			}
+
+			notifications := eventing.newNotificationChan()
+			eventing.subscribe("a", notifications)
+			for _, n := range tt.queued {
+				eventing.emitToAll(n)
+			}
+
+			// emitting does not block on a full queue
+			eventing.emitToAll(tt.notification)
+
+			if tt.wantDisconnected {
+				require.NotContains(t, eventing.subs, "a")
+				require.Len(t, drain(notifications), len(tt.queued))
+				_, open := <-notifications
+				require.False(t, open, "expected the notification channel to be closed")
+				return
+			}
+
+			require.Equal(t, map[string]int{"1": len(tt.want)}, eventing.queueDepths())
+			require.Equal(t, tt.want, drain(notifications))
+		})
+	}
+}
+
+func TestQueueDepths(t *testing.T) {
+	eventing := &eventingConfiguration{
+		subs:      make(map[interface{}]*subscription),
+		mu:        &sync.RWMutex{},
+		queueSize: 4,
+	}
+	require.Empty(t, eventing.queueDepths())
+
+	lagging := eventing.newNotificationChan()
+	eventing.subscribe("lagging", lagging)
+	eventing.emitToAll(iservice.Notification{Type: iservice.ConfigurationChange})
+	eventing.emitToAll(iservice.Notification{Type: iservice.ConfigurationChange})
+
+	eventing.subscribe("late", eventing.newNotificationChan())
+	eventing.emitToAll(iservice.Notification{Type: iservice.ConfigurationChange})
+	require.Equal(t, map[string]int{"1": 3, "2": 1}, eventing.queueDepths())
+
+	drain(lagging)
+	eventing.unSubscribe("late")
+	require.Equal(t, map[string]int{"1": 0}, eventing.queueDepths())
+}
+
+func TestCoalesce_namespaces(t *testing.T) {
+	change := func(namespace string, revision int) map[string]interface{} {
+		details := map[string]interface{}{"type": "update", "source": "file", "revision": revision}
+		if namespace != "" {
+			details["namespace"] = namespace
+		}
+		return details
+	}
+	notification := func(key string, details map[string]interface{}) iservice.Notification {
+		return iservice.Notification{
+			Type: iservice.ConfigurationChange,
+			Data: map[string]interface{}{"flags": map[string]interface{}{key: details}},
+		}
+	}
+
+	// the same flag key in different namespaces is kept apart, the latest change of a flag of a namespace wins
+	coalesced := coalesce([]iservice.Notification{
+		notification("color", change("", 1)),
+		notification("color", change("team-b", 1)),
+		notification("color", change("", 2)),
+		notification("size", change("team-b", 2)),
+	})
+	require.Equal(t, []iservice.Notification{
+		{
+			Type: iservice.ConfigurationChange,
+			Data: map[string]interface{}{"flags": map[string]interface{}{"color": change("", 2)}},
+		},
+		{
+			Type: iservice.ConfigurationChange,
+			Data: map[string]interface{}{"flags": map[string]interface{}{
+				"color": change("team-b", 1),
+				"size":  change("team-b", 2),
+			}},
+		},
+	}, coalesced)
+}
+
+func TestParseOverflowPolicy(t *testing.T) {
+	policy, err := ParseOverflowPolicy("coalesce")
+	require.NoError(t, err)
+	require.Equal(t, OverflowCoalesce, policy)
+
+	_, err = ParseOverflowPolicy("block")
+	require.Error(t, err)
 }
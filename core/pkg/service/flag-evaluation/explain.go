@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"connectrpc.com/connect"
+)
+
+// ExplainHeader is the request header opting into the explain mode of flag evaluations. The trace of an explained
+// evaluation is returned in the response metadata.
+const ExplainHeader = "Flagd-Explain"
+
+type explainContextKey struct{}
+
+// explainInterceptor marks the context of unary requests which opted into the explain mode
+func explainInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if explain, _ := strconv.ParseBool(req.Header().Get(ExplainHeader)); explain {
+				ctx = context.WithValue(ctx, explainContextKey{}, true)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+func explainRequested(ctx context.Context) bool {
+	explain, _ := ctx.Value(explainContextKey{}).(bool)
+	return explain
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	evalV1 "buf.build/gen/go/open-feature/flagd/protocolbuffers/go/flagd/evaluation/v1"
+	"connectrpc.com/connect"
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const explainFlags = `{
+  "flags": {
+    "color": {
+      "state": "ENABLED",
+      "variants": {
+        "red": "#FF0000",
+        "blue": "#0000FF"
+      },
+      "defaultVariant": "red",
+      "targeting": {
+        "if": [ { "==": [ { "var": "email" }, "user@example.com" ] }, "blue" ]
+      }
+    }
+  }
+}`
+
+func TestExplain(t *testing.T) {
+	tests := map[string]struct {
+		header      string
+		wantExplain bool
+	}{
+		"explain requested": {
+			header:      "true",
+			wantExplain: true,
+		},
+		"explain not requested": {
+			header:      "",
+			wantExplain: false,
+		},
+		"explain disabled": {
+			header:      "false",
+			wantExplain: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			eval := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags())
+			_, _, err := eval.SetState(sync.DataSync{FlagData: explainFlags})
+			require.NoError(t, err)
+
+			metrics, _ := getMetricReader()
+			s := NewFlagEvaluationService(logger.NewLogger(nil, false), eval, &eventingConfiguration{}, metrics)
+
+			handler := explainInterceptor()(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				return s.ResolveString(ctx, req.(*connect.Request[evalV1.ResolveStringRequest]))
+			})
+
+			evalCtx, err := structpb.NewStruct(map[string]interface{}{"email": "user@example.com"})
+			require.NoError(t, err)
+			req := connect.NewRequest(&evalV1.ResolveStringRequest{FlagKey: "color", Context: evalCtx})
+			req.Header().Set(ExplainHeader, tt.header)
+
+			res, err := handler(context.Background(), req)
+			require.NoError(t, err)
+
+			msg, ok := res.Any().(*evalV1.ResolveStringResponse)
+			require.True(t, ok)
+			require.Equal(t, "blue", msg.GetVariant())
+
+			explanation, ok := msg.GetMetadata().AsMap()[evaluator.ExplainMetadataKey]
+			require.Equal(t, tt.wantExplain, ok)
+			if tt.wantExplain {
+				trace, ok := explanation.(map[string]interface{})
+				require.True(t, ok)
+				require.Equal(t, "color", trace["flagKey"])
+				require.NotEmpty(t, trace["steps"])
+			}
+		})
+	}
+}
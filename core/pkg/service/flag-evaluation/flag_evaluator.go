@@ -67,7 +67,8 @@ func (s *OldFlagEvaluationService) ResolveAll(
 	span.SetAttributes(attribute.Int("feature_flag.count", len(values)))
 	for _, value := range values {
 		// register the impression and reason for each flag evaluated
-		s.metrics.RecordEvaluation(sCtx, value.Error, value.Reason, value.Variant, value.FlagKey)
+		s.metrics.RecordEvaluation(
+			sCtx, value.Error, evaluator.NamespaceFromContext(sCtx), value.Reason, value.Variant, value.FlagKey)
 		switch v := value.Value.(type) {
 		case bool:
 			res.Flags[value.FlagKey] = &schemaV1.AnyFlag{
@@ -116,13 +117,17 @@ func (s *OldFlagEvaluationService) EventStream(
 	req *connect.Request[schemaV1.EventStreamRequest],
 	stream *connect.ServerStream[schemaV1.EventStreamResponse],
 ) error {
-	requestNotificationChan := make(chan service.Notification, 1)
-	s.eventingConfiguration.subscribe(req, requestNotificationChan)
-	defer s.eventingConfiguration.unSubscribe(req)
-
+	requestNotificationChan := s.eventingConfiguration.newNotificationChan()
 	requestNotificationChan <- service.Notification{
 		Type: service.ProviderReady,
 	}
+
+	s.eventingConfiguration.subscribe(req, requestNotificationChan)
+	defer s.eventingConfiguration.unSubscribe(req)
+	// configuration changes are narrowed down to the namespace and the selector of the subscriber
+	namespace := req.Header().Get(NamespaceHeader)
+	selector := req.Header().Get(SelectorHeader)
+
 	for {
 		select {
 		case <-time.After(20 * time.Second):
@@ -132,7 +137,14 @@ func (s *OldFlagEvaluationService) EventStream(
 			if err != nil {
 				s.logger.Error(err.Error())
 			}
-		case notification := <-requestNotificationChan:
+		case notification, ok := <-requestNotificationChan:
+			if !ok {
+				return connect.NewError(connect.CodeResourceExhausted, errDisconnectedSubscriber)
+			}
+			notification, ok = selectNotification(notification, namespace, selector)
+			if !ok {
+				continue
+			}
 			d, err := structpb.NewStruct(notification.Data)
 			if err != nil {
 				s.logger.Error(err.Error())
@@ -287,6 +299,11 @@ func resolve[T constraints](ctx context.Context, logger *logger.Logger, resolver
 		zap.Strings("context-keys", formatContextKeys(evaluationContext)),
 	)
 
+	var explanation *evaluator.Trace
+	if explainRequested(ctx) {
+		ctx, explanation = evaluator.WithTrace(ctx)
+	}
+
 	var evalErrFormatted error
 	result, variant, reason, metadata, evalErr := resolver(ctx, reqID, flagKey, evaluationContext.AsMap())
 	if evalErr != nil {
@@ -295,7 +312,11 @@ func resolve[T constraints](ctx context.Context, logger *logger.Logger, resolver
 		evalErrFormatted = errFormat(evalErr)
 	}
 
-	metrics.RecordEvaluation(ctx, evalErr, reason, variant, flagKey)
+	if explanation != nil {
+		metadata = withExplanation(logger, reqID, metadata, explanation)
+	}
+
+	metrics.RecordEvaluation(ctx, evalErr, evaluator.NamespaceFromContext(ctx), reason, variant, flagKey)
 
 	spanFromContext := trace.SpanFromContext(ctx)
 	spanFromContext.SetAttributes(telemetry.SemConvFeatureFlagAttributes(flagKey, variant)...)
@@ -308,6 +329,24 @@ func resolve[T constraints](ctx context.Context, logger *logger.Logger, resolver
 	return evalErrFormatted
 }
 
+// withExplanation adds the trace of the evaluation to its metadata
+func withExplanation(
+	logger *logger.Logger, reqID string, metadata map[string]interface{}, explanation *evaluator.Trace,
+) map[string]interface{} {
+	trace, err := explanation.AsMap()
+	if err != nil {
+		logger.ErrorWithID(reqID, fmt.Sprintf("explanation construction: %v", err))
+		return metadata
+	}
+
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata[evaluator.ExplainMetadataKey] = trace
+
+	return metadata
+}
+
 func formatContextKeys(context *structpb.Struct) []string {
 	res := []string{}
 	for k := range context.AsMap() {
@@ -66,7 +66,8 @@ func (s *FlagEvaluationService) ResolveAll(
 	span.SetAttributes(attribute.Int("feature_flag.count", len(values)))
 	for _, value := range values {
 		// register the impression and reason for each flag evaluated
-		s.metrics.RecordEvaluation(sCtx, value.Error, value.Reason, value.Variant, value.FlagKey)
+		s.metrics.RecordEvaluation(
+			sCtx, value.Error, evaluator.NamespaceFromContext(sCtx), value.Reason, value.Variant, value.FlagKey)
 		switch v := value.Value.(type) {
 		case bool:
 			res.Flags[value.FlagKey] = &evalV1.AnyFlag{
@@ -115,13 +116,17 @@ func (s *FlagEvaluationService) EventStream(
 	req *connect.Request[evalV1.EventStreamRequest],
 	stream *connect.ServerStream[evalV1.EventStreamResponse],
 ) error {
-	requestNotificationChan := make(chan service.Notification, 1)
-	s.eventingConfiguration.subscribe(req, requestNotificationChan)
-	defer s.eventingConfiguration.unSubscribe(req)
-
+	requestNotificationChan := s.eventingConfiguration.newNotificationChan()
 	requestNotificationChan <- service.Notification{
 		Type: service.ProviderReady,
 	}
+
+	s.eventingConfiguration.subscribe(req, requestNotificationChan)
+	defer s.eventingConfiguration.unSubscribe(req)
+	// configuration changes are narrowed down to the namespace and the selector of the subscriber
+	namespace := req.Header().Get(NamespaceHeader)
+	selector := req.Header().Get(SelectorHeader)
+
 	for {
 		select {
 		case <-time.After(20 * time.Second):
@@ -131,7 +136,14 @@ func (s *FlagEvaluationService) EventStream(
 			if err != nil {
 				s.logger.Error(err.Error())
 			}
-		case notification := <-requestNotificationChan:
+		case notification, ok := <-requestNotificationChan:
+			if !ok {
+				return connect.NewError(connect.CodeResourceExhausted, errDisconnectedSubscriber)
+			}
+			notification, ok = selectNotification(notification, namespace, selector)
+			if !ok {
+				continue
+			}
 			d, err := structpb.NewStruct(notification.Data)
 			if err != nil {
 				s.logger.Error(err.Error())
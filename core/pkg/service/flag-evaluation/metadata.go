@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+)
+
+// MetadataFilterHeader is the request header narrowing bulk flag evaluations down to the flags whose metadata matches
+// its comma separated key=value pairs
+const MetadataFilterHeader = "Flagd-Metadata-Filter"
+
+// metadataFilterInterceptor narrows the bulk flag evaluations of unary requests down to the metadata filter of their
+// header
+func metadataFilterInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			return next(withRequestMetadataFilter(ctx, req.Header()), req)
+		}
+	}
+}
+
+func withRequestMetadataFilter(ctx context.Context, header http.Header) context.Context {
+	if filter := header.Get(MetadataFilterHeader); filter != "" {
+		return evaluator.WithMetadataFilter(ctx, filter)
+	}
+	return ctx
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	evalV1 "buf.build/gen/go/open-feature/flagd/protocolbuffers/go/flagd/evaluation/v1"
+	"connectrpc.com/connect"
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+)
+
+func TestMetadataFilter(t *testing.T) {
+	eval := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags())
+	_, _, err := eval.SetState(sync.DataSync{FlagData: `{"metadata":{"owner":"platform"},"flags":{
+		"a":{"state":"ENABLED","variants":{"on":true},"defaultVariant":"on","metadata":{"owner":"checkout"}},
+		"b":{"state":"ENABLED","variants":{"on":true},"defaultVariant":"on"}
+	}}`, Source: "A"})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		filter string
+		want   []string
+	}{
+		"no filter":  {want: []string{"a", "b"}},
+		"flag":       {filter: "owner=checkout", want: []string{"a"}},
+		"flag set":   {filter: "owner=platform", want: []string{"b"}},
+		"no matches": {filter: "owner=search", want: []string{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			metrics, _ := getMetricReader()
+			svc := NewFlagEvaluationService(logger.NewLogger(nil, false), eval, &eventingConfiguration{}, metrics)
+			handler := metadataFilterInterceptor()(
+				func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+					return svc.ResolveAll(ctx, req.(*connect.Request[evalV1.ResolveAllRequest]))
+				})
+
+			req := connect.NewRequest(&evalV1.ResolveAllRequest{})
+			req.Header().Set(MetadataFilterHeader, tt.filter)
+
+			res, err := handler(context.Background(), req)
+			require.NoError(t, err)
+			msg, ok := res.Any().(*evalV1.ResolveAllResponse)
+			require.True(t, ok)
+			require.ElementsMatch(t, tt.want, maps.Keys(msg.GetFlags()))
+		})
+	}
+}
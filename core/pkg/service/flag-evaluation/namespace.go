@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+)
+
+// NamespaceHeader is the request header selecting the namespace whose flags are evaluated, the default namespace
+// being evaluated without it
+const NamespaceHeader = "Flagd-Namespace"
+
+// namespaceInterceptor performs the flag evaluations of unary requests against the namespace of their header
+func namespaceInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			return next(withRequestNamespace(ctx, req.Header()), req)
+		}
+	}
+}
+
+func withRequestNamespace(ctx context.Context, header http.Header) context.Context {
+	if namespace := header.Get(NamespaceHeader); namespace != "" {
+		return evaluator.WithNamespace(ctx, namespace)
+	}
+	return ctx
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	evalV1 "buf.build/gen/go/open-feature/flagd/protocolbuffers/go/flagd/evaluation/v1"
+	"connectrpc.com/connect"
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/open-feature/flagd/core/pkg/telemetry"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNamespace(t *testing.T) {
+	s := store.NewFlags()
+	s.FlagSources = []string{"A", "B"}
+	s.SourceMetadata = map[string]store.SourceDetails{
+		"A": {Source: "A"},
+		"B": {Source: "B", Namespace: "team"},
+	}
+	s.Namespace("team").FlagSources = []string{"B"}
+	eval := evaluator.NewJSON(logger.NewLogger(nil, false), s)
+	for source, color := range map[string]string{"A": "red", "B": "blue"} {
+		_, _, err := eval.SetState(sync.DataSync{
+			FlagData: `{"flags":{"color":{"state":"ENABLED","variants":{"v":"` + color + `"},"defaultVariant":"v"}}}`,
+			Source:   source,
+		})
+		require.NoError(t, err)
+	}
+
+	tests := map[string]struct {
+		namespace string
+		want      string
+	}{
+		"default namespace": {want: "red"},
+		"namespace":         {namespace: "team", want: "blue"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			metrics, exp := getMetricReader()
+			svc := NewFlagEvaluationService(logger.NewLogger(nil, false), eval, &eventingConfiguration{}, metrics)
+			handler := namespaceInterceptor()(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				return svc.ResolveString(ctx, req.(*connect.Request[evalV1.ResolveStringRequest]))
+			})
+
+			req := connect.NewRequest(&evalV1.ResolveStringRequest{FlagKey: "color"})
+			req.Header().Set(NamespaceHeader, tt.namespace)
+
+			res, err := handler(context.Background(), req)
+			require.NoError(t, err)
+			msg, ok := res.Any().(*evalV1.ResolveStringResponse)
+			require.True(t, ok)
+			require.Equal(t, tt.want, msg.GetValue())
+
+			// evaluations are recorded along with their namespace, unless it is the default namespace
+			var data metricdata.ResourceMetrics
+			require.NoError(t, exp.Collect(context.TODO(), &data))
+			for _, m := range data.ScopeMetrics[0].Metrics {
+				for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+					namespace, ok := point.Attributes.Value(telemetry.FeatureFlagNamespaceKey)
+					require.Equal(t, tt.namespace != "", ok)
+					require.Equal(t, tt.namespace, namespace.AsString())
+				}
+			}
+		})
+	}
+}
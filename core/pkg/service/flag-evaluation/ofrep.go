@@ -0,0 +1,247 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/telemetry"
+	"github.com/rs/xid"
+)
+
+const (
+	ofrepPrefix   = "/ofrep/"
+	ofrepFlagsURL = "/ofrep/v1/evaluate/flags"
+
+	// ofrep specific error codes, complementing the ones of the model package
+	ofrepInvalidContextErrorCode = "INVALID_CONTEXT"
+)
+
+// ofrepRequest is the request body of the OFREP evaluation endpoints
+type ofrepRequest struct {
+	Context map[string]any `json:"context"`
+}
+
+// ofrepEvaluationSuccess is the OFREP representation of a successful flag evaluation
+type ofrepEvaluationSuccess struct {
+	Key      string                 `json:"key"`
+	Value    interface{}            `json:"value"`
+	Reason   string                 `json:"reason"`
+	Variant  string                 `json:"variant"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+// ofrepEvaluationError is the OFREP representation of a failed flag evaluation
+type ofrepEvaluationError struct {
+	Key          string `json:"key,omitempty"`
+	ErrorCode    string `json:"errorCode"`
+	ErrorDetails string `json:"errorDetails,omitempty"`
+}
+
+// ofrepBulkEvaluationResponse holds either ofrepEvaluationSuccess or ofrepEvaluationError entries
+type ofrepBulkEvaluationResponse struct {
+	Flags []interface{} `json:"flags"`
+}
+
+// ofrepHandler serves the OpenFeature Remote Evaluation Protocol endpoints on top of the evaluator
+type ofrepHandler struct {
+	logger  *logger.Logger
+	eval    evaluator.IEvaluator
+	metrics *telemetry.MetricsRecorder
+}
+
+func newOfrepHandler(logger *logger.Logger, eval evaluator.IEvaluator, metrics *telemetry.MetricsRecorder) http.Handler {
+	h := &ofrepHandler{
+		logger:  logger,
+		eval:    eval,
+		metrics: metrics,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(ofrepFlagsURL, h.bulkEvaluation)
+	mux.HandleFunc(ofrepFlagsURL+"/", h.flagEvaluation)
+	return mux
+}
+
+// flagEvaluation serves POST /ofrep/v1/evaluate/flags/{key}
+func (h *ofrepHandler) flagEvaluation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	flagKey := strings.TrimPrefix(r.URL.Path, ofrepFlagsURL+"/")
+	if flagKey == "" {
+		h.writeJSON(w, http.StatusBadRequest, ofrepEvaluationError{
+			ErrorCode:    model.GeneralErrorCode,
+			ErrorDetails: "flag key is missing",
+		})
+		return
+	}
+
+	evalCtx, err := ofrepContext(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, ofrepEvaluationError{
+			Key:          flagKey,
+			ErrorCode:    ofrepInvalidContextErrorCode,
+			ErrorDetails: err.Error(),
+		})
+		return
+	}
+
+	reqID := xid.New().String()
+	defer h.logger.ClearFields(reqID)
+
+	ctx := withRequestNamespace(withRequestSelector(r.Context(), r.Header), r.Header)
+	value := h.eval.ResolveAsAnyValue(ctx, reqID, flagKey, evalCtx)
+	h.metrics.RecordEvaluation(
+		ctx, value.Error, evaluator.NamespaceFromContext(ctx), value.Reason, value.Variant, value.FlagKey)
+	if value.Error != nil {
+		status, evaluationError := ofrepError(value)
+		h.writeJSON(w, status, evaluationError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ofrepSuccess(value))
+}
+
+// bulkEvaluation serves POST /ofrep/v1/evaluate/flags. The response carries an ETag, allowing clients to poll using
+// If-None-Match.
+func (h *ofrepHandler) bulkEvaluation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	evalCtx, err := ofrepContext(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, ofrepEvaluationError{
+			ErrorCode:    ofrepInvalidContextErrorCode,
+			ErrorDetails: err.Error(),
+		})
+		return
+	}
+
+	reqID := xid.New().String()
+	defer h.logger.ClearFields(reqID)
+
+	ctx := withRequestNamespace(withRequestSelector(r.Context(), r.Header), r.Header)
+	values := h.eval.ResolveAllValues(withRequestMetadataFilter(ctx, r.Header), reqID, evalCtx)
+	// the order of the flags must be stable for the ETag to be meaningful
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].FlagKey < values[j].FlagKey
+	})
+
+	res := ofrepBulkEvaluationResponse{Flags: make([]interface{}, 0, len(values))}
+	for _, value := range values {
+		h.metrics.RecordEvaluation(
+			ctx, value.Error, evaluator.NamespaceFromContext(ctx), value.Reason, value.Variant, value.FlagKey)
+		if value.Error != nil {
+			_, evaluationError := ofrepError(value)
+			res.Flags = append(res.Flags, evaluationError)
+		} else {
+			res.Flags = append(res.Flags, ofrepSuccess(value))
+		}
+	}
+
+	body, err := json.Marshal(res)
+	if err != nil {
+		h.logger.ErrorWithID(reqID, fmt.Sprintf("error marshalling bulk evaluation response: %v", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	eTag := fmt.Sprintf("%q", hex.EncodeToString(sum[:]))
+	w.Header().Set("ETag", eTag)
+	if r.Header.Get("If-None-Match") == eTag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		h.logger.ErrorWithID(reqID, fmt.Sprintf("error writing bulk evaluation response: %v", err))
+	}
+}
+
+func (h *ofrepHandler) writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		h.logger.Error(fmt.Sprintf("error writing ofrep response: %v", err))
+	}
+}
+
+// ofrepContext extracts the evaluation context from the request body, an empty body results in an empty context
+func ofrepContext(r *http.Request) (map[string]any, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+
+	evalCtx := map[string]any{}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return evalCtx, nil
+	}
+
+	var req ofrepRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("context is not a valid JSON object: %w", err)
+	}
+	if req.Context != nil {
+		evalCtx = req.Context
+	}
+
+	return evalCtx, nil
+}
+
+func ofrepSuccess(value evaluator.AnyValue) ofrepEvaluationSuccess {
+	metadata := value.Metadata
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+
+	return ofrepEvaluationSuccess{
+		Key:      value.FlagKey,
+		Value:    value.Value,
+		Reason:   value.Reason,
+		Variant:  value.Variant,
+		Metadata: metadata,
+	}
+}
+
+// ofrepError maps the error of a failed flag evaluation to its OFREP status code and payload
+func ofrepError(value evaluator.AnyValue) (int, ofrepEvaluationError) {
+	payload := ofrepEvaluationError{Key: value.FlagKey}
+
+	switch value.Error.Error() {
+	case model.FlagNotFoundErrorCode:
+		payload.ErrorCode = model.FlagNotFoundErrorCode
+		payload.ErrorDetails = fmt.Sprintf("flag `%s` does not exist", value.FlagKey)
+		return http.StatusNotFound, payload
+	case model.FlagDisabledErrorCode:
+		// OFREP has no notion of disabled flags, they are not found from a client point of view
+		payload.ErrorCode = model.FlagNotFoundErrorCode
+		payload.ErrorDetails = fmt.Sprintf("flag `%s` is disabled", value.FlagKey)
+		return http.StatusNotFound, payload
+	case model.ParseErrorCode:
+		payload.ErrorCode = model.ParseErrorCode
+		payload.ErrorDetails = fmt.Sprintf("error parsing the flag `%s`", value.FlagKey)
+		return http.StatusBadRequest, payload
+	default:
+		payload.ErrorCode = model.GeneralErrorCode
+		payload.ErrorDetails = fmt.Sprintf("error evaluating the flag `%s`: %s", value.FlagKey, value.Error)
+		return http.StatusBadRequest, payload
+	}
+}
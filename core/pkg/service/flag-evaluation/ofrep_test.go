@@ -0,0 +1,182 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+const ofrepFlags = `{
+  "flags": {
+    "color": {
+      "state": "ENABLED",
+      "variants": {
+        "red": "#FF0000",
+        "blue": "#0000FF"
+      },
+      "defaultVariant": "red",
+      "targeting": {
+        "if": [ { "==": [ { "var": "email" }, "user@example.com" ] }, "blue" ]
+      }
+    },
+    "disabled": {
+      "state": "DISABLED",
+      "variants": {
+        "on": true,
+        "off": false
+      },
+      "defaultVariant": "off"
+    }
+  }
+}`
+
+func newTestOfrepHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	eval := evaluator.NewJSON(logger.NewLogger(nil, false), store.NewFlags())
+	_, _, err := eval.SetState(sync.DataSync{FlagData: ofrepFlags})
+	require.NoError(t, err)
+
+	metrics, _ := getMetricReader()
+	return newOfrepHandler(logger.NewLogger(nil, false), eval, metrics)
+}
+
+func TestOfrep_flagEvaluation(t *testing.T) {
+	tests := map[string]struct {
+		method     string
+		flagKey    string
+		body       string
+		wantStatus int
+		wantBody   map[string]interface{}
+	}{
+		"targeting match": {
+			method:     http.MethodPost,
+			flagKey:    "color",
+			body:       `{"context":{"email":"user@example.com"}}`,
+			wantStatus: http.StatusOK,
+			wantBody: map[string]interface{}{
+				"key":      "color",
+				"value":    "#0000FF",
+				"reason":   model.TargetingMatchReason,
+				"variant":  "blue",
+				"metadata": map[string]interface{}{"revision": float64(1)},
+			},
+		},
+		"empty body": {
+			method:     http.MethodPost,
+			flagKey:    "color",
+			wantStatus: http.StatusOK,
+			wantBody: map[string]interface{}{
+				"key":      "color",
+				"value":    "#FF0000",
+				"reason":   model.DefaultReason,
+				"variant":  "red",
+				"metadata": map[string]interface{}{"revision": float64(1)},
+			},
+		},
+		"flag not found": {
+			method:     http.MethodPost,
+			flagKey:    "missing",
+			body:       `{"context":{}}`,
+			wantStatus: http.StatusNotFound,
+			wantBody: map[string]interface{}{
+				"key":          "missing",
+				"errorCode":    model.FlagNotFoundErrorCode,
+				"errorDetails": "flag `missing` does not exist",
+			},
+		},
+		"flag disabled": {
+			method:     http.MethodPost,
+			flagKey:    "disabled",
+			body:       `{"context":{}}`,
+			wantStatus: http.StatusNotFound,
+			wantBody: map[string]interface{}{
+				"key":          "disabled",
+				"errorCode":    model.FlagNotFoundErrorCode,
+				"errorDetails": "flag `disabled` is disabled",
+			},
+		},
+		"invalid context": {
+			method:     http.MethodPost,
+			flagKey:    "color",
+			body:       `{"context":"invalid"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		"method not allowed": {
+			method:     http.MethodGet,
+			flagKey:    "color",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := newTestOfrepHandler(t)
+
+			req := httptest.NewRequest(tt.method, ofrepFlagsURL+"/"+tt.flagKey, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != nil {
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				require.Equal(t, tt.wantBody, body)
+			}
+			if tt.wantStatus == http.StatusBadRequest {
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				require.Equal(t, ofrepInvalidContextErrorCode, body["errorCode"])
+			}
+		})
+	}
+}
+
+func TestOfrep_bulkEvaluation(t *testing.T) {
+	handler := newTestOfrepHandler(t)
+	body := `{"context":{"email":"user@example.com"}}`
+
+	req := httptest.NewRequest(http.MethodPost, ofrepFlagsURL, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	eTag := rec.Header().Get("ETag")
+	require.NotEmpty(t, eTag)
+
+	var res struct {
+		Flags []map[string]interface{} `json:"flags"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	// disabled flags are not part of bulk evaluations
+	require.Len(t, res.Flags, 1)
+	require.Equal(t, "color", res.Flags[0]["key"])
+	require.Equal(t, "blue", res.Flags[0]["variant"])
+
+	// unchanged evaluations are not sent again
+	req = httptest.NewRequest(http.MethodPost, ofrepFlagsURL, strings.NewReader(body))
+	req.Header.Set("If-None-Match", eTag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.Bytes())
+
+	// a different context results in different evaluations
+	req = httptest.NewRequest(http.MethodPost, ofrepFlagsURL, strings.NewReader(`{"context":{}}`))
+	req.Header.Set("If-None-Match", eTag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotEqual(t, eTag, rec.Header().Get("ETag"))
+}
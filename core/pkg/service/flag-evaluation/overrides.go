@@ -0,0 +1,138 @@
+package service
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/service"
+	"github.com/open-feature/flagd/core/pkg/sync/override"
+)
+
+const adminOverridesURL = "/admin/overrides"
+
+// overrideRequest is the request body of PUT /admin/overrides/{key}, the ttl is a duration such as 15m
+type overrideRequest struct {
+	Variant  string `json:"variant"`
+	Disabled bool   `json:"disabled"`
+	TTL      string `json:"ttl"`
+}
+
+type overridesResponse struct {
+	Overrides []model.Override `json:"overrides"`
+}
+
+// overridesHandler serves the runtime flag overrides API of the management port. Requests must carry the admin token
+// as bearer token, the API is disabled if no token is configured.
+type overridesHandler struct {
+	logger    *logger.Logger
+	overrides service.OverrideManager
+	token     string
+}
+
+func newOverridesHandler(logger *logger.Logger, overrides service.OverrideManager, token string) http.Handler {
+	h := &overridesHandler{
+		logger:    logger,
+		overrides: overrides,
+		token:     token,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(adminOverridesURL, h.list)
+	mux.HandleFunc(adminOverridesURL+"/", h.override)
+	return h.authenticate(mux)
+}
+
+func (h *overridesHandler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.token == "" || h.overrides == nil {
+			http.Error(w, "the override API is disabled, an admin token is required to enable it", http.StatusForbidden)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// list serves GET /admin/overrides
+func (h *overridesHandler) list(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, overridesResponse{Overrides: h.overrides.List()})
+}
+
+// override serves PUT and DELETE /admin/overrides/{key}, flags of other namespaces than the default namespace are
+// selected by the namespace query parameter
+func (h *overridesHandler) override(w http.ResponseWriter, r *http.Request) {
+	flagKey := strings.TrimPrefix(r.URL.Path, adminOverridesURL+"/")
+	if flagKey == "" {
+		http.Error(w, "flag key is missing", http.StatusBadRequest)
+		return
+	}
+	namespace := r.URL.Query().Get(adminNamespaceParam)
+
+	switch r.Method {
+	case http.MethodPut:
+		h.set(w, r, namespace, flagKey)
+	case http.MethodDelete:
+		if !h.overrides.Delete(namespace, flagKey) {
+			http.Error(w, fmt.Sprintf("flag %s is not overridden", flagKey), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *overridesHandler) set(w http.ResponseWriter, r *http.Request, namespace string, flagKey string) {
+	var req overrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid override request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 {
+			http.Error(w, fmt.Sprintf("invalid ttl '%s', expected a positive duration such as 15m", req.TTL),
+				http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := h.overrides.Set(namespace, flagKey, req.Variant, req.Disabled, ttl)
+	switch {
+	case errors.Is(err, override.ErrFlagNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		h.writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func (h *overridesHandler) writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		h.logger.Error(fmt.Sprintf("error writing overrides response: %v", err))
+	}
+}
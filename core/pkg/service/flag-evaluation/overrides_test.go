@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/open-feature/flagd/core/pkg/sync/override"
+	"github.com/stretchr/testify/require"
+)
+
+const testAdminToken = "secret"
+
+func newTestOverridesHandler(t *testing.T, token string) http.Handler {
+	t.Helper()
+
+	log := logger.NewLogger(nil, false)
+	flags := store.NewFlags()
+	flags.Merge(log, "A", map[string]model.Flag{
+		"color": {State: "ENABLED", DefaultVariant: "red", Variants: map[string]any{"red": "#FF0000", "blue": "#0000FF"}},
+	})
+	flags.Namespace("team-b").Merge(log, "B", map[string]model.Flag{
+		"size": {State: "ENABLED", DefaultVariant: "small", Variants: map[string]any{"small": 1, "large": 2}},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	overrides := override.NewSync(log, flags)
+	dataSync := make(chan sync.DataSync)
+	go func() {
+		for {
+			select {
+			case <-dataSync:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		_ = overrides.Sync(ctx, dataSync)
+	}()
+
+	return newOverridesHandler(log, overrides, token)
+}
+
+func overridesRequest(method string, path string, body string, token string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestOverrides_authentication(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestOverridesHandler(t, "").ServeHTTP(rec, overridesRequest(http.MethodGet, adminOverridesURL, "", "any"))
+	require.Equal(t, http.StatusForbidden, rec.Code, "the API is disabled without token")
+
+	handler := newTestOverridesHandler(t, testAdminToken)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, overridesRequest(http.MethodGet, adminOverridesURL, "", ""))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, overridesRequest(http.MethodGet, adminOverridesURL, "", "invalid"))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, overridesRequest(http.MethodGet, adminOverridesURL, "", testAdminToken))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestOverrides(t *testing.T) {
+	tests := map[string]struct {
+		method     string
+		flagKey    string
+		query      string
+		body       string
+		wantStatus int
+	}{
+		"force variant": {
+			method:     http.MethodPut,
+			flagKey:    "color",
+			body:       `{"variant":"blue","ttl":"15m"}`,
+			wantStatus: http.StatusOK,
+		},
+		"disable": {
+			method:     http.MethodPut,
+			flagKey:    "color",
+			body:       `{"disabled":true}`,
+			wantStatus: http.StatusOK,
+		},
+		"unknown flag": {
+			method:     http.MethodPut,
+			flagKey:    "missing",
+			body:       `{"variant":"blue"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		"unknown variant": {
+			method:     http.MethodPut,
+			flagKey:    "color",
+			body:       `{"variant":"green"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		"invalid ttl": {
+			method:     http.MethodPut,
+			flagKey:    "color",
+			body:       `{"variant":"blue","ttl":"soon"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		"flag of a namespace": {
+			method:     http.MethodPut,
+			flagKey:    "size",
+			query:      "?namespace=team-b",
+			body:       `{"variant":"large"}`,
+			wantStatus: http.StatusOK,
+		},
+		"flag of another namespace": {
+			method:     http.MethodPut,
+			flagKey:    "size",
+			body:       `{"variant":"large"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		"unknown namespace": {
+			method:     http.MethodPut,
+			flagKey:    "color",
+			query:      "?namespace=team-c",
+			body:       `{"variant":"blue"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		"delete missing override": {
+			method:     http.MethodDelete,
+			flagKey:    "color",
+			wantStatus: http.StatusNotFound,
+		},
+		"method not allowed": {
+			method:     http.MethodPost,
+			flagKey:    "color",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := newTestOverridesHandler(t, testAdminToken)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec,
+				overridesRequest(tt.method, adminOverridesURL+"/"+tt.flagKey+tt.query, tt.body, testAdminToken))
+			require.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestOverrides_lifecycle(t *testing.T) {
+	handler := newTestOverridesHandler(t, testAdminToken)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec,
+		overridesRequest(http.MethodPut, adminOverridesURL+"/color", `{"variant":"blue","ttl":"1h"}`, testAdminToken))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var created model.Override
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	require.Equal(t, "color", created.FlagKey)
+	require.Equal(t, "blue", created.Variant)
+	require.NotNil(t, created.ExpiresAt)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, overridesRequest(http.MethodGet, adminOverridesURL, "", testAdminToken))
+	var listed overridesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listed))
+	require.Len(t, listed.Overrides, 1)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, overridesRequest(http.MethodDelete, adminOverridesURL+"/color?namespace=team-b", "",
+		testAdminToken))
+	require.Equal(t, http.StatusNotFound, rec.Code, "overrides are deleted from their namespace")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, overridesRequest(http.MethodDelete, adminOverridesURL+"/color", "", testAdminToken))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, overridesRequest(http.MethodGet, adminOverridesURL, "", testAdminToken))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listed))
+	require.Empty(t, listed.Overrides)
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+)
+
+// SelectorHeader is the request header narrowing the flags seen by flag evaluations down to the sources matching the
+// selector, either through the selector configured for them or through their URI
+const SelectorHeader = "Flagd-Selector"
+
+// selectorInterceptor narrows the flag evaluations of unary requests down to the selector of their header
+func selectorInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			return next(withRequestSelector(ctx, req.Header()), req)
+		}
+	}
+}
+
+func withRequestSelector(ctx context.Context, header http.Header) context.Context {
+	if selector := header.Get(SelectorHeader); selector != "" {
+		return evaluator.WithSelector(ctx, selector)
+	}
+	return ctx
+}
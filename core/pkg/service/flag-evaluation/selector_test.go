@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	evalV1 "buf.build/gen/go/open-feature/flagd/protocolbuffers/go/flagd/evaluation/v1"
+	"connectrpc.com/connect"
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelector(t *testing.T) {
+	s := store.NewFlags()
+	s.FlagSources = []string{"A", "B"}
+	s.SourceMetadata = map[string]store.SourceDetails{
+		"A": {Source: "A", Selector: "app=a"},
+		"B": {Source: "B", Selector: "app=b"},
+	}
+	eval := evaluator.NewJSON(logger.NewLogger(nil, false), s)
+	for source, color := range map[string]string{"A": "red", "B": "blue"} {
+		_, _, err := eval.SetState(sync.DataSync{
+			FlagData: `{"flags":{"color":{"state":"ENABLED","variants":{"v":"` + color + `"},"defaultVariant":"v"}}}`,
+			Source:   source,
+		})
+		require.NoError(t, err)
+	}
+
+	tests := map[string]struct {
+		selector string
+		want     string
+		wantErr  bool
+	}{
+		"no selector":        {want: "blue"},
+		"shadowed source":    {selector: "app=a", want: "red"},
+		"source uri":         {selector: "B", want: "blue"},
+		"no matching source": {selector: "app=c", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			metrics, _ := getMetricReader()
+			svc := NewFlagEvaluationService(logger.NewLogger(nil, false), eval, &eventingConfiguration{}, metrics)
+			handler := selectorInterceptor()(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				return svc.ResolveString(ctx, req.(*connect.Request[evalV1.ResolveStringRequest]))
+			})
+
+			req := connect.NewRequest(&evalV1.ResolveStringRequest{FlagKey: "color"})
+			req.Header().Set(SelectorHeader, tt.selector)
+
+			res, err := handler(context.Background(), req)
+			if tt.wantErr {
+				require.ErrorContains(t, err, model.FlagNotFoundErrorCode)
+				return
+			}
+			require.NoError(t, err)
+			msg, ok := res.Any().(*evalV1.ResolveStringResponse)
+			require.True(t, ok)
+			require.Equal(t, tt.want, msg.GetValue())
+		})
+	}
+}
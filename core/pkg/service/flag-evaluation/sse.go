@@ -0,0 +1,147 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/service"
+)
+
+const (
+	sseEventsURL         = "/events"
+	sseSelectorParam     = "selector"
+	sseNamespaceParam    = "namespace"
+	sseKeepAliveInterval = 20 * time.Second
+)
+
+// sseHandler streams the notifications of the eventing configuration as Server-Sent Events, for clients which can't
+// consume connect streams such as browsers or curl
+type sseHandler struct {
+	logger                *logger.Logger
+	eventingConfiguration *eventingConfiguration
+	keepAliveInterval     time.Duration
+}
+
+func newSSEHandler(logger *logger.Logger, eventingCfg *eventingConfiguration) *sseHandler {
+	return &sseHandler{
+		logger:                logger,
+		eventingConfiguration: eventingCfg,
+		keepAliveInterval:     sseKeepAliveInterval,
+	}
+}
+
+// ServeHTTP serves GET /events. Configuration changes are those of the namespace of the namespace query parameter or
+// of the Flagd-Namespace header, and can be narrowed down to a selector with the selector query parameter or the
+// Flagd-Selector header.
+func (h *sseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	namespace := queryOrHeader(r, sseNamespaceParam, NamespaceHeader)
+	selector := queryOrHeader(r, sseSelectorParam, SelectorHeader)
+
+	requestNotificationChan := h.eventingConfiguration.newNotificationChan()
+	h.eventingConfiguration.subscribe(r, requestNotificationChan)
+	defer h.eventingConfiguration.unSubscribe(r)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	h.send(w, flusher, service.Notification{Type: service.ProviderReady})
+
+	keepAlive := time.NewTicker(h.keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-keepAlive.C:
+			h.send(w, flusher, service.Notification{Type: service.KeepAlive})
+		case notification, ok := <-requestNotificationChan:
+			if !ok {
+				// disconnected by the overflow policy, clients reconnect on their own
+				return
+			}
+			if notification, ok := selectNotification(notification, namespace, selector); ok {
+				h.send(w, flusher, notification)
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// send writes the notification as an event named after its type, with its data as JSON payload
+func (h *sseHandler) send(w http.ResponseWriter, flusher http.Flusher, notification service.Notification) {
+	data := notification.Data
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("error marshalling %s event: %v", notification.Type, err))
+		return
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", notification.Type, payload); err != nil {
+		h.logger.Error(fmt.Sprintf("error writing %s event: %v", notification.Type, err))
+		return
+	}
+	flusher.Flush()
+}
+
+// queryOrHeader returns the value of the query parameter of the request, or of the header if the parameter is not set
+func queryOrHeader(r *http.Request, param string, header string) string {
+	if value := r.URL.Query().Get(param); value != "" {
+		return value
+	}
+	return r.Header.Get(header)
+}
+
+// selectNotification narrows configuration changes down to the flags of the namespace, the default namespace if it is
+// empty, and to those matching the selector, either through the selector configured for their source or through the
+// source itself. Other notifications are not affected. False is returned if none of the changed flags match.
+func selectNotification(
+	notification service.Notification, namespace string, selector string,
+) (service.Notification, bool) {
+	if notification.Type != service.ConfigurationChange {
+		return notification, true
+	}
+
+	flags, ok := notification.Data["flags"].(map[string]interface{})
+	if !ok {
+		return notification, true
+	}
+
+	selected := map[string]interface{}{}
+	for key, change := range flags {
+		details, ok := change.(map[string]interface{})
+		if !ok || changeNamespace(change) != namespace {
+			continue
+		}
+		if selector == "" || details["selector"] == selector || details["source"] == selector {
+			selected[key] = change
+		}
+	}
+	if len(selected) == 0 {
+		return notification, false
+	}
+
+	return service.Notification{
+		Type: notification.Type,
+		Data: map[string]interface{}{
+			"flags": selected,
+		},
+	}, true
+}
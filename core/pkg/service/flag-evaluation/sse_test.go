@@ -0,0 +1,226 @@
+package service
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	iservice "github.com/open-feature/flagd/core/pkg/service"
+	"github.com/stretchr/testify/require"
+)
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+func readSSEEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	t.Helper()
+
+	var event sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return event
+		case strings.HasPrefix(line, "event: "):
+			event.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestSSE(t *testing.T) {
+	eventing := &eventingConfiguration{
+		subs: make(map[interface{}]*subscription),
+		mu:   &sync.RWMutex{},
+	}
+	handler := newSSEHandler(logger.NewLogger(nil, false), eventing)
+	handler.keepAliveInterval = 500 * time.Millisecond
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "?selector=app=a")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(res.Body)
+	require.Equal(t, sseEvent{name: "provider_ready", data: "{}"}, readSSEEvent(t, reader))
+
+	// changes of other selectors are skipped, changed flags of the requested selector are sent
+	eventing.emitToAll(iservice.Notification{
+		Type: iservice.ConfigurationChange,
+		Data: map[string]interface{}{
+			"flags": map[string]interface{}{
+				"other": map[string]interface{}{"type": "write", "source": "B", "selector": "app=b"},
+			},
+		},
+	})
+	eventing.emitToAll(iservice.Notification{
+		Type: iservice.ConfigurationChange,
+		Data: map[string]interface{}{
+			"flags": map[string]interface{}{
+				"mine":  map[string]interface{}{"type": "update", "source": "A", "selector": "app=a"},
+				"other": map[string]interface{}{"type": "update", "source": "B", "selector": "app=b"},
+			},
+		},
+	})
+
+	event := readSSEEvent(t, reader)
+	require.Equal(t, "configuration_change", event.name)
+	require.JSONEq(t, `{"flags":{"mine":{"type":"update","source":"A","selector":"app=a"}}}`, event.data)
+
+	require.Equal(t, sseEvent{name: "keep_alive", data: "{}"}, readSSEEvent(t, reader))
+}
+
+func TestSSE_namespace(t *testing.T) {
+	eventing := &eventingConfiguration{
+		subs: make(map[interface{}]*subscription),
+		mu:   &sync.RWMutex{},
+	}
+	server := httptest.NewServer(newSSEHandler(logger.NewLogger(nil, false), eventing))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set(NamespaceHeader, "team-b")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	reader := bufio.NewReader(res.Body)
+	require.Equal(t, sseEvent{name: "provider_ready", data: "{}"}, readSSEEvent(t, reader))
+
+	// changes of the default namespace are skipped, changed flags of the namespace of the subscriber are sent
+	eventing.emitToAll(iservice.Notification{
+		Type: iservice.ConfigurationChange,
+		Data: map[string]interface{}{
+			"flags": map[string]interface{}{
+				"color": map[string]interface{}{"type": "update", "source": "A"},
+			},
+		},
+	})
+	eventing.emitToAll(iservice.Notification{
+		Type: iservice.ConfigurationChange,
+		Data: map[string]interface{}{
+			"flags": map[string]interface{}{
+				"color": map[string]interface{}{"type": "update", "source": "B", "namespace": "team-b"},
+			},
+		},
+	})
+
+	event := readSSEEvent(t, reader)
+	require.Equal(t, "configuration_change", event.name)
+	require.JSONEq(t, `{"flags":{"color":{"type":"update","source":"B","namespace":"team-b"}}}`, event.data)
+}
+
+func TestSSE_methodNotAllowed(t *testing.T) {
+	eventing := &eventingConfiguration{
+		subs: make(map[interface{}]*subscription),
+		mu:   &sync.RWMutex{},
+	}
+	handler := newSSEHandler(logger.NewLogger(nil, false), eventing)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, sseEventsURL, nil))
+
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.Empty(t, eventing.subs)
+}
+
+func TestSelectNotification(t *testing.T) {
+	change := iservice.Notification{
+		Type: iservice.ConfigurationChange,
+		Data: map[string]interface{}{
+			"flags": map[string]interface{}{
+				"a": map[string]interface{}{"type": "write", "source": "file.json", "selector": "app=a"},
+				"b": map[string]interface{}{"type": "write", "source": "other.json"},
+				"c": map[string]interface{}{"type": "write", "source": "team.json", "namespace": "team-b"},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		notification iservice.Notification
+		namespace    string
+		selector     string
+		wantFlags    []string
+		wantOk       bool
+	}{
+		"no selector": {
+			notification: change,
+			wantFlags:    []string{"a", "b"},
+			wantOk:       true,
+		},
+		"namespace": {
+			notification: change,
+			namespace:    "team-b",
+			wantFlags:    []string{"c"},
+			wantOk:       true,
+		},
+		"namespace and selector": {
+			notification: change,
+			namespace:    "team-b",
+			selector:     "app=a",
+			wantOk:       false,
+		},
+		"unknown namespace": {
+			notification: change,
+			namespace:    "team-c",
+			wantOk:       false,
+		},
+		"matching selector": {
+			notification: change,
+			selector:     "app=a",
+			wantFlags:    []string{"a"},
+			wantOk:       true,
+		},
+		"matching source": {
+			notification: change,
+			selector:     "other.json",
+			wantFlags:    []string{"b"},
+			wantOk:       true,
+		},
+		"no match": {
+			notification: change,
+			selector:     "app=c",
+			wantOk:       false,
+		},
+		"other notification type": {
+			notification: iservice.Notification{Type: iservice.Shutdown},
+			selector:     "app=c",
+			wantOk:       true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := selectNotification(tt.notification, tt.namespace, tt.selector)
+			require.Equal(t, tt.wantOk, ok)
+			if tt.wantFlags == nil {
+				return
+			}
+
+			flags, isMap := got.Data["flags"].(map[string]interface{})
+			require.True(t, isMap)
+			keys := make([]string, 0, len(flags))
+			for key := range flags {
+				keys = append(keys, key)
+			}
+			require.ElementsMatch(t, tt.wantFlags, keys)
+		})
+	}
+}
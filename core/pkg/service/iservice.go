@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/store"
 )
 
 type NotificationType string
@@ -22,8 +25,47 @@ type Notification struct {
 
 type ReadinessProbe func() bool
 
+// SyncRejection describes why the last flag configuration received from a source was rejected
+type SyncRejection struct {
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+// SyncRejectionProbe returns the rejections of flag sources whose last flag configuration was rejected
+type SyncRejectionProbe func() map[string]SyncRejection
+
+// SourceStatus describes a configured flag source, LastSync is the time of its last accepted flag configuration
+type SourceStatus struct {
+	Source    string     `json:"source"`
+	Provider  string     `json:"provider"`
+	Selector  string     `json:"selector,omitempty"`
+	Namespace string     `json:"namespace,omitempty"`
+	Ready     bool       `json:"ready"`
+	LastSync  *time.Time `json:"lastSync,omitempty"`
+	// Metadata describes the last flag configuration of the source, such as the commit it was read from
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// SourceStatusProbe returns the status of the configured flag sources
+type SourceStatusProbe func() []SourceStatus
+
+// OverrideManager forces flags of a namespace to a variant or disables them at runtime, a positive ttl lets an override
+// expire
+type OverrideManager interface {
+	Set(namespace string, key string, variant string, disabled bool, ttl time.Duration) (model.Override, error)
+	Delete(namespace string, key string) bool
+	List() []model.Override
+}
+
 type Configuration struct {
 	ReadinessProbe ReadinessProbe
+	SyncRejections SyncRejectionProbe
+	SourceStatuses SourceStatusProbe
+	// FlagStore is inspected by the admin API of the management port
+	FlagStore *store.Flags
+	// Overrides are managed through the management port by clients authenticated with the AdminToken
+	Overrides      OverrideManager
+	AdminToken     string
 	Port           uint16
 	ManagementPort uint16
 	ServiceName    string
@@ -3,6 +3,8 @@ package sync
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 
 	"buf.build/gen/go/open-feature/flagd/grpc/go/flagd/sync/v1/syncv1grpc"
 	rpc "buf.build/gen/go/open-feature/flagd/grpc/go/sync/v1/syncv1grpc"
@@ -11,8 +13,14 @@ import (
 	"github.com/open-feature/flagd/core/pkg/logger"
 	"github.com/open-feature/flagd/core/pkg/subscriptions"
 	"github.com/open-feature/flagd/core/pkg/sync"
+	grpcsync "github.com/open-feature/flagd/core/pkg/sync/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
+// deltaSnapshotInterval is the number of payloads after which subscribers receiving deltas get the full configuration
+const deltaSnapshotInterval = 100
+
 type handler struct {
 	syncv1grpc.UnimplementedFlagSyncServiceServer
 	syncStore subscriptions.Manager
@@ -30,13 +38,29 @@ func (nh *handler) SyncFlags(
 	errChan := make(chan error)
 	dataSync := make(chan sync.DataSync)
 	nh.syncStore.RegisterSubscription(ctx, request.GetSelector(), request, dataSync, errChan)
+
+	var deltas *grpcsync.DeltaEncoder
+	if deltasRequested(server.Context()) {
+		deltas = grpcsync.NewDeltaEncoder(deltaSnapshotInterval)
+	}
+
 	for {
 		select {
 		case e := <-errChan:
 			return e
 		case d := <-dataSync:
+			flagConfiguration := d.FlagData
+			if deltas != nil {
+				delta, err := deltas.Encode(d.FlagData, grpcsync.RevisionOf(d.Metadata))
+				if err != nil {
+					nh.logger.Warn(fmt.Sprintf("unable to compute flag configuration delta, sending it in full: %v", err))
+				} else {
+					flagConfiguration = delta
+				}
+			}
+
 			if err := server.Send(&syncv12.SyncFlagsResponse{
-				FlagConfiguration: d.FlagData,
+				FlagConfiguration: flagConfiguration,
 			}); err != nil {
 				return fmt.Errorf("error sending configuration change event: %w", err)
 			}
@@ -57,6 +81,13 @@ func (nh *handler) FetchAllFlags(
 		return &syncv12.FetchAllFlagsResponse{}, fmt.Errorf("error fetching all flags from sync store: %w", err)
 	}
 
+	if revision := grpcsync.RevisionOf(data.Metadata); revision > 0 {
+		header := metadata.Pairs(grpcsync.RevisionHeaderKey, strconv.FormatUint(revision, 10))
+		if err := grpc.SetHeader(ctx, header); err != nil {
+			nh.logger.Warn(fmt.Sprintf("unable to send the flag configuration revision: %v", err))
+		}
+	}
+
 	return &syncv12.FetchAllFlagsResponse{
 		FlagConfiguration: data.FlagData,
 	}, nil
@@ -114,6 +145,16 @@ func (l *oldHandler) SyncFlags(
 	}
 }
 
+// deltasRequested checks whether the subscriber announced applying delta payloads
+func deltasRequested(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	return slices.Contains(md.Get(grpcsync.DeltaMetadataKey), "true")
+}
+
 func dataSyncToGrpcState(s sync.DataSync) syncv1.SyncState {
 	return syncv1.SyncState(s.Type + 1)
 }
@@ -0,0 +1,172 @@
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	msync "sync"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/sync"
+)
+
+// snapshot is the content of a snapshot file
+type snapshot struct {
+	Source   string                 `json:"source"`
+	SavedAt  time.Time              `json:"savedAt"`
+	FlagData string                 `json:"flagData"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Dir persists the last flag configuration applied from each source as a JSON file of the directory, so that flags
+// can be served from these snapshots when sources are unreachable on startup
+type Dir struct {
+	path string
+	mx   msync.Mutex
+}
+
+// NewDir creates the snapshot directory if it does not exist yet
+func NewDir(path string) (*Dir, error) {
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating snapshot directory %s: %w", path, err)
+	}
+
+	return &Dir{path: path}, nil
+}
+
+// Load returns the flag configuration last saved for the source along with its metadata, false is returned if there
+// is none
+func (d *Dir) Load(source string) (sync.DataSync, bool, error) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	s, ok, err := d.read(source)
+	if err != nil || !ok {
+		return sync.DataSync{}, ok, err
+	}
+	return sync.DataSync{FlagData: s.FlagData, Source: source, Type: sync.ALL, Metadata: s.Metadata}, true, nil
+}
+
+// Save persists the applied flag configuration along with its metadata. Partial configurations (ADD, UPDATE and
+// DELETE) are merged into the configuration saved for the source, keeping its metadata unless they carry their own.
+// The file is replaced atomically.
+func (d *Dir) Save(payload sync.DataSync) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	flagData := payload.FlagData
+	metadata := payload.Metadata
+	if payload.Type != sync.ALL {
+		previous, ok, err := d.read(payload.Source)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// the full configuration of the source isn't known, keep going without snapshot
+			return nil
+		}
+		if flagData, err = merge(previous.FlagData, payload); err != nil {
+			return err
+		}
+		if metadata == nil {
+			metadata = previous.Metadata
+		}
+	}
+
+	data, err := json.Marshal(snapshot{
+		Source:   payload.Source,
+		SavedAt:  time.Now(),
+		FlagData: flagData,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling snapshot of source %s: %w", payload.Source, err)
+	}
+
+	tmp, err := os.CreateTemp(d.path, ".snapshot-*")
+	if err != nil {
+		return fmt.Errorf("error creating snapshot of source %s: %w", payload.Source, err)
+	}
+	defer func() {
+		// already renamed unless writing failed
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("error writing snapshot of source %s: %w", payload.Source, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing snapshot of source %s: %w", payload.Source, err)
+	}
+	if err := os.Rename(tmp.Name(), d.file(payload.Source)); err != nil {
+		return fmt.Errorf("error replacing snapshot of source %s: %w", payload.Source, err)
+	}
+
+	return nil
+}
+
+func (d *Dir) read(source string) (snapshot, bool, error) {
+	data, err := os.ReadFile(d.file(source))
+	if errors.Is(err, os.ErrNotExist) {
+		return snapshot{}, false, nil
+	}
+	if err != nil {
+		return snapshot{}, false, fmt.Errorf("error reading snapshot of source %s: %w", source, err)
+	}
+
+	var s snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return snapshot{}, false, fmt.Errorf("error parsing snapshot of source %s: %w", source, err)
+	}
+	return s, true, nil
+}
+
+// file names snapshots after the hash of their source, as source URIs are not valid file names
+func (d *Dir) file(source string) string {
+	hash := sha256.Sum256([]byte(source))
+	return filepath.Join(d.path, hex.EncodeToString(hash[:])+".json")
+}
+
+// merge applies a partial flag configuration to a full one. Top level properties other than the flags are taken over
+// from added and updated configurations.
+func merge(flagData string, payload sync.DataSync) (string, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(flagData), &config); err != nil {
+		return "", fmt.Errorf("error parsing snapshot of source %s: %w", payload.Source, err)
+	}
+	var partial map[string]interface{}
+	if err := json.Unmarshal([]byte(payload.FlagData), &partial); err != nil {
+		return "", fmt.Errorf("error parsing flag configuration of source %s: %w", payload.Source, err)
+	}
+
+	flags, _ := config["flags"].(map[string]interface{})
+	if flags == nil {
+		flags = map[string]interface{}{}
+	}
+	changed, _ := partial["flags"].(map[string]interface{})
+
+	for key, flag := range changed {
+		if payload.Type == sync.DELETE {
+			delete(flags, key)
+		} else {
+			flags[key] = flag
+		}
+	}
+	if payload.Type != sync.DELETE {
+		for key, value := range partial {
+			config[key] = value
+		}
+	}
+	config["flags"] = flags
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling snapshot of source %s: %w", payload.Source, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,83 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDir(t *testing.T) {
+	dir, err := NewDir(filepath.Join(t.TempDir(), "snapshots"))
+	require.NoError(t, err)
+
+	const source = "https://example.com/flags.json"
+
+	_, ok, err := dir.Load(source)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// partial configurations are ignored until the full configuration is known
+	require.NoError(t, dir.Save(sync.DataSync{FlagData: `{"flags":{"b":{}}}`, Source: source, Type: sync.ADD}))
+	_, ok, err = dir.Load(source)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, dir.Save(sync.DataSync{
+		FlagData: `{"flags":{"a":{}}}`,
+		Source:   source,
+		Type:     sync.ALL,
+		Metadata: map[string]interface{}{"commit": "abc"},
+	}))
+	loaded, ok, err := dir.Load(source)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, sync.DataSync{
+		FlagData: `{"flags":{"a":{}}}`,
+		Source:   source,
+		Type:     sync.ALL,
+		Metadata: map[string]interface{}{"commit": "abc"},
+	}, loaded)
+
+	require.NoError(t, dir.Save(sync.DataSync{
+		FlagData: `{"flags":{"b":{"state":"ENABLED"}},"$evaluators":{"x":true}}`,
+		Source:   source,
+		Type:     sync.ADD,
+	}))
+	require.NoError(t, dir.Save(sync.DataSync{FlagData: `{"flags":{"a":{}}}`, Source: source, Type: sync.DELETE}))
+	loaded, _, err = dir.Load(source)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"flags":{"b":{"state":"ENABLED"}},"$evaluators":{"x":true}}`, loaded.FlagData)
+	require.Equal(t, map[string]interface{}{"commit": "abc"}, loaded.Metadata, "partial configurations keep the metadata")
+
+	// partial configurations carrying metadata replace it
+	require.NoError(t, dir.Save(sync.DataSync{
+		FlagData: `{"flags":{"c":{}}}`,
+		Source:   source,
+		Type:     sync.UPDATE,
+		Metadata: map[string]interface{}{"commit": "def"},
+	}))
+	loaded, _, err = dir.Load(source)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"commit": "def"}, loaded.Metadata)
+
+	// snapshots of other sources are kept apart
+	_, ok, err = dir.Load("other")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	entries, err := os.ReadDir(dir.path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files are cleaned up")
+}
+
+func TestDir_corrupted(t *testing.T) {
+	dir, err := NewDir(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(dir.file("source"), []byte("invalid"), 0o600))
+	_, _, err = dir.Load("source")
+	require.Error(t, err)
+}
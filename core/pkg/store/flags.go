@@ -4,22 +4,73 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/open-feature/flagd/core/pkg/logger"
 	"github.com/open-feature/flagd/core/pkg/model"
+	"golang.org/x/exp/maps"
 )
 
+// DefaultHistorySize is the default number of previous flag definitions kept by the store
+const DefaultHistorySize = 100
+
+// Flags is the flag store. FlagSources and SourceMetadata are set up before the store is used, they are read with the
+// lock held like the flags.
 type Flags struct {
 	mx             sync.RWMutex
 	Flags          map[string]model.Flag `json:"flags"`
 	FlagSources    []string
 	SourceMetadata map[string]SourceDetails
+
+	// revision is bumped by every Merge, Add, Update or DeleteFlags call changing at least one flag
+	revision  uint64
+	revisions map[string]FlagRevision
+	// history holds the previous flag definitions, oldest first, up to historySize entries
+	history     []FlagHistoryEntry
+	historySize int
+
+	// cachedSources holds the sources whose flags were loaded from a snapshot and not synced live yet
+	cachedSources map[string]bool
+	// syncMetadata holds the metadata of the last flag configuration of each source
+	syncMetadata map[string]map[string]interface{}
+	// sourceFlags holds the flags of each source, including those shadowed by sources of higher priority
+	sourceFlags map[string]map[string]model.Flag
+
+	// namespace is the name of the namespace of the store, empty for the default namespace
+	namespace string
+	// namespaces holds the stores of the other namespaces, the store itself being the default namespace
+	namespaces map[string]*Flags
+}
+
+// FlagRevision identifies the change which last wrote a flag
+type FlagRevision struct {
+	Revision  uint64    `json:"revision"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// FlagHistoryEntry is a flag definition replaced or deleted by the change of revision ReplacedAt
+type FlagHistoryEntry struct {
+	FlagRevision
+	Key        string     `json:"key"`
+	Flag       model.Flag `json:"flag"`
+	ReplacedAt uint64     `json:"replacedAt"`
+}
+
+type FlagsOption func(*Flags)
+
+// WithHistorySize sets the number of previous flag definitions kept by the store, zero disables the history
+func WithHistorySize(size int) FlagsOption {
+	return func(f *Flags) {
+		f.historySize = size
+	}
 }
 
 type SourceDetails struct {
-	Source   string
-	Selector string
+	Source    string
+	Selector  string
+	Namespace string `json:",omitempty"`
 }
 
 func (f *Flags) hasPriority(stored string, new string) bool {
@@ -37,11 +88,17 @@ func (f *Flags) hasPriority(stored string, new string) bool {
 	return true
 }
 
-func NewFlags() *Flags {
-	return &Flags{
+func NewFlags(opts ...FlagsOption) *Flags {
+	f := &Flags{
 		Flags:          map[string]model.Flag{},
 		SourceMetadata: map[string]SourceDetails{},
+		historySize:    DefaultHistorySize,
+	}
+	for _, opt := range opts {
+		opt(f)
 	}
+
+	return f
 }
 
 func (f *Flags) Set(key string, flag model.Flag) {
@@ -58,6 +115,211 @@ func (f *Flags) Get(key string) (model.Flag, bool) {
 	return flag, ok
 }
 
+// Revision returns the current revision of the flag configuration, zero until the first change
+func (f *Flags) Revision() uint64 {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	return f.revision
+}
+
+// FlagRevision returns the revision which last wrote the flag
+func (f *Flags) FlagRevision(key string) (FlagRevision, bool) {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	revision, ok := f.revisions[key]
+
+	return revision, ok
+}
+
+// Revisions returns a copy of the revisions which last wrote each flag
+func (f *Flags) Revisions() map[string]FlagRevision {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	revisions := make(map[string]FlagRevision, len(f.revisions))
+
+	for key, revision := range f.revisions {
+		revisions[key] = revision
+	}
+
+	return revisions
+}
+
+// History returns the previous definitions of the flag still kept by the store, oldest first. All flags are
+// included if the key is empty.
+func (f *Flags) History(key string) []FlagHistoryEntry {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	history := []FlagHistoryEntry{}
+	for _, entry := range f.history {
+		if key == "" || entry.Key == key {
+			history = append(history, entry)
+		}
+	}
+	return history
+}
+
+// SetSourceCached marks the flags of the source as loaded from a snapshot, or as synced live
+func (f *Flags) SetSourceCached(source string, cached bool) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if !cached {
+		delete(f.cachedSources, source)
+		return
+	}
+	if f.cachedSources == nil {
+		f.cachedSources = map[string]bool{}
+	}
+	f.cachedSources[source] = true
+}
+
+// IsSourceCached checks whether the flags of the source were loaded from a snapshot and not synced live yet
+func (f *Flags) IsSourceCached(source string) bool {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	return f.cachedSources[source]
+}
+
+// SetSyncMetadata sets the metadata of the last flag configuration of the source, nil clears it
+func (f *Flags) SetSyncMetadata(source string, metadata map[string]interface{}) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if len(metadata) == 0 {
+		delete(f.syncMetadata, source)
+		return
+	}
+	if f.syncMetadata == nil {
+		f.syncMetadata = map[string]map[string]interface{}{}
+	}
+	f.syncMetadata[source] = maps.Clone(metadata)
+}
+
+// SyncMetadata returns a copy of the metadata of the last flag configuration of the source, nil if there is none
+func (f *Flags) SyncMetadata(source string) map[string]interface{} {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	return maps.Clone(f.syncMetadata[source])
+}
+
+// GetSelected returns the flag as seen by the sources matching the selector, from the source of highest priority among
+// them. Sources match a selector either through the selector configured for them or through the source itself. All
+// sources match an empty selector, as for Get.
+func (f *Flags) GetSelected(key string, selector string) (model.Flag, bool) {
+	if selector == "" {
+		return f.Get(key)
+	}
+
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	for i := len(f.FlagSources) - 1; i >= 0; i-- {
+		source := f.FlagSources[i]
+		if !f.matches(source, selector) {
+			continue
+		}
+		if flag, ok := f.sourceFlags[source][key]; ok {
+			return flag, true
+		}
+	}
+
+	return model.Flag{}, false
+}
+
+// GetAllSelected returns a copy of the flags of the sources matching the selector, merged by priority. All sources
+// match an empty selector, as for GetAll.
+func (f *Flags) GetAllSelected(selector string) map[string]model.Flag {
+	if selector == "" {
+		return f.GetAll()
+	}
+
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	state := map[string]model.Flag{}
+	for _, source := range f.FlagSources {
+		if !f.matches(source, selector) {
+			continue
+		}
+		for key, flag := range f.sourceFlags[source] {
+			state[key] = flag
+		}
+	}
+
+	return state
+}
+
+// Shadowed returns the definition of the flag from the source of highest priority other than the given one, that is
+// the definition stored once the flag of the given source is gone. Sources without a configured priority come last.
+func (f *Flags) Shadowed(key string, source string) (model.Flag, bool) {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	prioritized := make(map[string]bool, len(f.FlagSources))
+	for i := len(f.FlagSources) - 1; i >= 0; i-- {
+		prioritized[f.FlagSources[i]] = true
+		if f.FlagSources[i] == source {
+			continue
+		}
+		if flag, ok := f.sourceFlags[f.FlagSources[i]][key]; ok {
+			return flag, true
+		}
+	}
+
+	others := []string{}
+	for other := range f.sourceFlags {
+		if other != source && !prioritized[other] {
+			others = append(others, other)
+		}
+	}
+	sort.Strings(others)
+	for _, other := range others {
+		if flag, ok := f.sourceFlags[other][key]; ok {
+			return flag, true
+		}
+	}
+
+	return model.Flag{}, false
+}
+
+// matches checks whether the source matches the selector, it must be called with the lock held
+func (f *Flags) matches(source string, selector string) bool {
+	return source == selector || f.SourceMetadata[source].Selector == selector
+}
+
+// setSourceFlags records flags of the source, replacing all of its flags if replace is set
+func (f *Flags) setSourceFlags(source string, flags map[string]model.Flag, replace bool) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if f.sourceFlags == nil {
+		f.sourceFlags = map[string]map[string]model.Flag{}
+	}
+	if replace || f.sourceFlags[source] == nil {
+		f.sourceFlags[source] = make(map[string]model.Flag, len(flags))
+	}
+	for key, flag := range flags {
+		flag.Source = source
+		f.sourceFlags[source][key] = flag
+	}
+}
+
+// deleteSourceFlags removes flags of the source, all of them if none is given
+func (f *Flags) deleteSourceFlags(source string, flags map[string]model.Flag) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if len(flags) == 0 {
+		delete(f.sourceFlags, source)
+		return
+	}
+	for key := range flags {
+		delete(f.sourceFlags[source], key)
+	}
+}
+
 func (f *Flags) SelectorForFlag(flag model.Flag) string {
 	f.mx.RLock()
 	defer f.mx.RUnlock()
@@ -65,16 +327,57 @@ func (f *Flags) SelectorForFlag(flag model.Flag) string {
 	return f.SourceMetadata[flag.Source].Selector
 }
 
+// notification describes a change of a flag from the given source, made by the change of the given revision. The
+// selector and the namespace of the source are included when configured, allowing consumers to filter notifications.
+func (f *Flags) notification(
+	notificationType model.StateChangeNotificationType, source string, revision uint64,
+) map[string]interface{} {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	notification := map[string]interface{}{
+		"type":     string(notificationType),
+		"source":   source,
+		"revision": revision,
+	}
+	if selector := f.SourceMetadata[source].Selector; selector != "" {
+		notification["selector"] = selector
+	}
+	if f.namespace != "" {
+		notification["namespace"] = f.namespace
+	}
+
+	return notification
+}
+
 func (f *Flags) Delete(key string) {
 	f.mx.Lock()
 	defer f.mx.Unlock()
 	delete(f.Flags, key)
 }
 
+// String returns the flags of the store along with their sources and the current revision, and the flags of the
+// other namespaces
 func (f *Flags) String() (string, error) {
 	f.mx.RLock()
 	defer f.mx.RUnlock()
-	bytes, err := json.Marshal(f)
+	namespaces := make(map[string]map[string]model.Flag, len(f.namespaces))
+	for name, ns := range f.namespaces {
+		namespaces[name] = ns.GetAll()
+	}
+	bytes, err := json.Marshal(struct {
+		Flags          map[string]model.Flag `json:"flags"`
+		FlagSources    []string
+		SourceMetadata map[string]SourceDetails
+		Revision       uint64                           `json:"revision"`
+		Namespaces     map[string]map[string]model.Flag `json:"namespaces,omitempty"`
+	}{
+		Flags:          f.Flags,
+		FlagSources:    f.FlagSources,
+		SourceMetadata: f.SourceMetadata,
+		Revision:       f.revision,
+		Namespaces:     namespaces,
+	})
 	if err != nil {
 		return "", fmt.Errorf("unable to marshal flags: %w", err)
 	}
@@ -98,6 +401,8 @@ func (f *Flags) GetAll() map[string]model.Flag {
 // Add new flags from source.
 func (f *Flags) Add(logger *logger.Logger, source string, flags map[string]model.Flag) map[string]interface{} {
 	notifications := map[string]interface{}{}
+	c := f.newChange()
+	f.setSourceFlags(source, flags, false)
 
 	for k, newFlag := range flags {
 		storedFlag, ok := f.Get(k)
@@ -113,14 +418,11 @@ func (f *Flags) Add(logger *logger.Logger, source string, flags map[string]model
 			continue
 		}
 
-		notifications[k] = map[string]interface{}{
-			"type":   string(model.NotificationCreate),
-			"source": source,
-		}
-
 		// Store the new version of the flag
 		newFlag.Source = source
-		f.Set(k, newFlag)
+		c.set(k, newFlag)
+
+		notifications[k] = f.notification(model.NotificationCreate, source, c.revision.Revision)
 	}
 
 	return notifications
@@ -129,6 +431,8 @@ func (f *Flags) Add(logger *logger.Logger, source string, flags map[string]model
 // Update existing flags from source.
 func (f *Flags) Update(logger *logger.Logger, source string, flags map[string]model.Flag) map[string]interface{} {
 	notifications := map[string]interface{}{}
+	c := f.newChange()
+	updated := map[string]model.Flag{}
 
 	for k, flag := range flags {
 		storedFlag, ok := f.Get(k)
@@ -140,6 +444,7 @@ func (f *Flags) Update(logger *logger.Logger, source string, flags map[string]mo
 
 			continue
 		}
+		updated[k] = flag
 		if !f.hasPriority(storedFlag.Source, source) {
 			logger.Debug(
 				fmt.Sprintf(
@@ -152,14 +457,12 @@ func (f *Flags) Update(logger *logger.Logger, source string, flags map[string]mo
 			continue
 		}
 
-		notifications[k] = map[string]interface{}{
-			"type":   string(model.NotificationUpdate),
-			"source": source,
-		}
-
 		flag.Source = source
-		f.Set(k, flag)
+		c.set(k, flag)
+
+		notifications[k] = f.notification(model.NotificationUpdate, source, c.revision.Revision)
 	}
+	f.setSourceFlags(source, updated, false)
 
 	return notifications
 }
@@ -173,17 +476,15 @@ func (f *Flags) DeleteFlags(logger *logger.Logger, source string, flags map[stri
 		),
 	)
 	notifications := map[string]interface{}{}
+	c := f.newChange()
+	f.deleteSourceFlags(source, flags)
 	if len(flags) == 0 {
 		allFlags := f.GetAll()
 		for key, flag := range allFlags {
 			if flag.Source != source {
 				continue
 			}
-			notifications[key] = map[string]interface{}{
-				"type":   string(model.NotificationDelete),
-				"source": source,
-			}
-			f.Delete(key)
+			notifications[key] = f.deleteFlag(c, key, source)
 		}
 	}
 
@@ -201,12 +502,7 @@ func (f *Flags) DeleteFlags(logger *logger.Logger, source string, flags map[stri
 				)
 				continue
 			}
-			notifications[k] = map[string]interface{}{
-				"type":   string(model.NotificationDelete),
-				"source": source,
-			}
-
-			f.Delete(k)
+			notifications[k] = f.deleteFlag(c, k, source)
 		} else {
 			logger.Warn(
 				fmt.Sprintf("failed to remove flag, flag with key %s from source %s does not exist.",
@@ -226,16 +522,13 @@ func (f *Flags) Merge(
 ) (map[string]interface{}, bool) {
 	notifications := map[string]interface{}{}
 	resyncRequired := false
-	f.mx.Lock()
-	for k, v := range f.Flags {
+	c := f.newChange()
+	f.setSourceFlags(source, flags, true)
+	for k, v := range f.GetAll() {
 		if v.Source == source {
 			if _, ok := flags[k]; !ok {
 				// flag has been deleted
-				delete(f.Flags, k)
-				notifications[k] = map[string]interface{}{
-					"type":   string(model.NotificationDelete),
-					"source": source,
-				}
+				notifications[k] = f.deleteFlag(c, k, source)
 				resyncRequired = true
 				logger.Debug(
 					fmt.Sprintf(
@@ -247,7 +540,6 @@ func (f *Flags) Merge(
 			}
 		}
 	}
-	f.mx.Unlock()
 	for k, newFlag := range flags {
 		newFlag.Source = source
 		storedFlag, ok := f.Get(k)
@@ -265,19 +557,97 @@ func (f *Flags) Merge(
 				continue
 			}
 		}
+		// Store the new version of the flag
+		c.set(k, newFlag)
 		if !ok {
-			notifications[k] = map[string]interface{}{
-				"type":   string(model.NotificationCreate),
-				"source": source,
-			}
+			notifications[k] = f.notification(model.NotificationCreate, source, c.revision.Revision)
 		} else {
-			notifications[k] = map[string]interface{}{
-				"type":   string(model.NotificationUpdate),
-				"source": source,
-			}
+			notifications[k] = f.notification(model.NotificationUpdate, source, c.revision.Revision)
 		}
-		// Store the new version of the flag
-		f.Set(k, newFlag)
 	}
 	return notifications, resyncRequired
 }
+
+// deleteFlag removes the flag of the source, whose flags must have been removed from sourceFlags already. The
+// definition of the flag shadowed by the source, if any, is stored in its place, so that removing an override or a
+// flag of a source of higher priority serves the flag of the other sources again.
+func (f *Flags) deleteFlag(c *change, key string, source string) map[string]interface{} {
+	if flag, ok := f.Shadowed(key, source); ok {
+		c.set(key, flag)
+		return f.notification(model.NotificationUpdate, flag.Source, c.revision.Revision)
+	}
+
+	c.delete(key)
+	return f.notification(model.NotificationDelete, source, c.revision.Revision)
+}
+
+// change writes the flags of a single Merge, Add, Update or DeleteFlags call. Its revision is allocated on the first
+// write, so that calls not changing any flag leave the revision of the store untouched.
+type change struct {
+	flags    *Flags
+	revision FlagRevision
+}
+
+func (f *Flags) newChange() *change {
+	return &change{flags: f}
+}
+
+// set stores the flag, archiving its previous definition
+func (c *change) set(key string, flag model.Flag) {
+	c.flags.mx.Lock()
+	defer c.flags.mx.Unlock()
+
+	c.stamp()
+	c.flags.archive(key, c.revision.Revision)
+	if c.flags.Flags == nil {
+		c.flags.Flags = map[string]model.Flag{}
+	}
+	if c.flags.revisions == nil {
+		c.flags.revisions = map[string]FlagRevision{}
+	}
+	c.flags.Flags[key] = flag
+	c.flags.revisions[key] = c.revision
+}
+
+// delete removes the flag, archiving its definition
+func (c *change) delete(key string) {
+	c.flags.mx.Lock()
+	defer c.flags.mx.Unlock()
+
+	c.stamp()
+	c.flags.archive(key, c.revision.Revision)
+	delete(c.flags.Flags, key)
+	delete(c.flags.revisions, key)
+}
+
+// stamp allocates the revision of the change if not done yet, it must be called with the write lock held
+func (c *change) stamp() {
+	if c.revision.Revision != 0 {
+		return
+	}
+
+	c.flags.revision++
+	c.revision = FlagRevision{
+		Revision:  c.flags.revision,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// archive appends the stored definition of the flag to the history, evicting the oldest entry once the history is
+// full. It must be called with the write lock held.
+func (f *Flags) archive(key string, replacedAt uint64) {
+	previous, ok := f.Flags[key]
+	if !ok || f.historySize <= 0 {
+		return
+	}
+
+	if len(f.history) >= f.historySize {
+		f.history = append(f.history[:0], f.history[len(f.history)-f.historySize+1:]...)
+	}
+	f.history = append(f.history, FlagHistoryEntry{
+		FlagRevision: f.revisions[key],
+		Key:          key,
+		Flag:         previous,
+		ReplacedAt:   replacedAt,
+	})
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"testing"
+	"time"
 
 	"github.com/open-feature/flagd/core/pkg/logger"
 	"github.com/open-feature/flagd/core/pkg/model"
@@ -144,7 +145,7 @@ func TestMergeFlags(t *testing.T) {
 				},
 			}},
 			wantNotifs: map[string]interface{}{
-				"paka": map[string]interface{}{"type": "write", "source": "2"},
+				"paka": map[string]interface{}{"type": "write", "source": "2", "revision": uint64(1)},
 			},
 		},
 		{
@@ -161,8 +162,8 @@ func TestMergeFlags(t *testing.T) {
 				"paka": {DefaultVariant: "on"},
 			}},
 			wantNotifs: map[string]interface{}{
-				"waka": map[string]interface{}{"type": "update", "source": ""},
-				"paka": map[string]interface{}{"type": "write", "source": ""},
+				"waka": map[string]interface{}{"type": "update", "source": "", "revision": uint64(1)},
+				"paka": map[string]interface{}{"type": "write", "source": "", "revision": uint64(1)},
 			},
 		},
 		{
@@ -187,10 +188,29 @@ func TestMergeFlags(t *testing.T) {
 			newSource: "A",
 			want:      &Flags{Flags: map[string]model.Flag{}},
 			wantNotifs: map[string]interface{}{
-				"hello": map[string]interface{}{"type": "delete", "source": "A"},
+				"hello": map[string]interface{}{"type": "delete", "source": "A", "revision": uint64(1)},
 			},
 			wantResync: true,
 		},
+		{
+			name: "source with selector",
+			current: &Flags{
+				Flags: map[string]model.Flag{},
+				SourceMetadata: map[string]SourceDetails{
+					"A": {Source: "A", Selector: "app=a"},
+				},
+			},
+			new: map[string]model.Flag{
+				"hello": {DefaultVariant: "off"},
+			},
+			newSource: "A",
+			want: &Flags{Flags: map[string]model.Flag{
+				"hello": {DefaultVariant: "off", Source: "A"},
+			}},
+			wantNotifs: map[string]interface{}{
+				"hello": map[string]interface{}{"type": "write", "source": "A", "revision": uint64(1), "selector": "app=a"},
+			},
+		},
 		{
 			name: "no merge priority",
 			current: &Flags{
@@ -237,6 +257,18 @@ func TestMergeFlags(t *testing.T) {
 	}
 }
 
+// clearRevisionTimes checks that the flags of the store are stamped with the time of the change which wrote them, and
+// clears these times so that the store can be compared to its expected state
+func clearRevisionTimes(t *testing.T, f *Flags) {
+	t.Helper()
+
+	for key, revision := range f.revisions {
+		require.Falsef(t, revision.UpdatedAt.IsZero(), "flag %s is not stamped with the time of its change", key)
+		revision.UpdatedAt = time.Time{}
+		f.revisions[key] = revision
+	}
+}
+
 func TestFlags_Add(t *testing.T) {
 	mockLogger := logger.NewLogger(nil, false)
 	mockSource := "source"
@@ -272,6 +304,11 @@ func TestFlags_Add(t *testing.T) {
 					"A": {Source: mockSource},
 					"B": {Source: mockSource},
 				},
+				revision:  1,
+				revisions: map[string]FlagRevision{"B": {Revision: 1}},
+				sourceFlags: map[string]map[string]model.Flag{
+					mockSource: {"B": {Source: mockSource}},
+				},
 			},
 			expectedNotificationKeys: []string{"B"},
 		},
@@ -295,6 +332,11 @@ func TestFlags_Add(t *testing.T) {
 					"B": {Source: mockSource},
 					"C": {Source: mockSource},
 				},
+				revision:  1,
+				revisions: map[string]FlagRevision{"B": {Revision: 1}, "C": {Revision: 1}},
+				sourceFlags: map[string]map[string]model.Flag{
+					mockSource: {"B": {Source: mockSource}, "C": {Source: mockSource}},
+				},
 			},
 			expectedNotificationKeys: []string{"B", "C"},
 		},
@@ -315,6 +357,11 @@ func TestFlags_Add(t *testing.T) {
 				Flags: map[string]model.Flag{
 					"A": {Source: mockOverrideSource},
 				},
+				revision:  1,
+				revisions: map[string]FlagRevision{"A": {Revision: 1}},
+				sourceFlags: map[string]map[string]model.Flag{
+					mockOverrideSource: {"A": {Source: mockOverrideSource}},
+				},
 			},
 			expectedNotificationKeys: []string{"A"},
 		},
@@ -323,6 +370,7 @@ func TestFlags_Add(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			messages := tt.storedState.Add(mockLogger, tt.addRequest.source, tt.addRequest.flags)
 
+			clearRevisionTimes(t, tt.storedState)
 			require.Equal(t, tt.storedState, tt.expectedState)
 
 			for k := range messages {
@@ -367,6 +415,11 @@ func TestFlags_Update(t *testing.T) {
 				Flags: map[string]model.Flag{
 					"A": {Source: mockSource, DefaultVariant: "False"},
 				},
+				revision:  1,
+				revisions: map[string]FlagRevision{"A": {Revision: 1}},
+				sourceFlags: map[string]map[string]model.Flag{
+					mockSource: {"A": {Source: mockSource, DefaultVariant: "False"}},
+				},
 			},
 			expectedNotificationKeys: []string{"A"},
 		},
@@ -390,6 +443,14 @@ func TestFlags_Update(t *testing.T) {
 					"A": {Source: mockSource, DefaultVariant: "False"},
 					"B": {Source: mockSource, DefaultVariant: "False"},
 				},
+				revision:  1,
+				revisions: map[string]FlagRevision{"A": {Revision: 1}, "B": {Revision: 1}},
+				sourceFlags: map[string]map[string]model.Flag{
+					mockSource: {
+						"A": {Source: mockSource, DefaultVariant: "False"},
+						"B": {Source: mockSource, DefaultVariant: "False"},
+					},
+				},
 			},
 			expectedNotificationKeys: []string{"A", "B"},
 		},
@@ -410,6 +471,11 @@ func TestFlags_Update(t *testing.T) {
 				Flags: map[string]model.Flag{
 					"A": {Source: mockOverrideSource, DefaultVariant: "True"},
 				},
+				revision:  1,
+				revisions: map[string]FlagRevision{"A": {Revision: 1}},
+				sourceFlags: map[string]map[string]model.Flag{
+					mockOverrideSource: {"A": {Source: mockOverrideSource, DefaultVariant: "True"}},
+				},
 			},
 			expectedNotificationKeys: []string{"A"},
 		},
@@ -430,6 +496,7 @@ func TestFlags_Update(t *testing.T) {
 				Flags: map[string]model.Flag{
 					"A": {Source: mockSource},
 				},
+				sourceFlags: map[string]map[string]model.Flag{mockSource: {}},
 			},
 			expectedNotificationKeys: []string{},
 		},
@@ -439,6 +506,7 @@ func TestFlags_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			messages := tt.storedState.Update(mockLogger, tt.UpdateRequest.source, tt.UpdateRequest.flags)
 
+			clearRevisionTimes(t, tt.storedState)
 			require.Equal(t, tt.storedState, tt.expectedState)
 
 			for k := range messages {
@@ -486,6 +554,7 @@ func TestFlags_Delete(t *testing.T) {
 					mockSource,
 					mockSource2,
 				},
+				revision: 1,
 			},
 			expectedNotificationKeys: []string{"A"},
 		},
@@ -532,6 +601,7 @@ func TestFlags_Delete(t *testing.T) {
 				Flags: map[string]model.Flag{
 					"C": {Source: mockSource2},
 				},
+				revision: 1,
 			},
 			expectedNotificationKeys: []string{"A", "B"},
 		},
@@ -540,6 +610,7 @@ func TestFlags_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			messages := tt.storedState.DeleteFlags(mockLogger, mockSource, tt.deleteRequest)
 
+			clearRevisionTimes(t, tt.storedState)
 			require.Equal(t, tt.storedState, tt.expectedState)
 
 			for k := range messages {
@@ -549,3 +620,136 @@ func TestFlags_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestFlags_Revisions(t *testing.T) {
+	log := logger.NewLogger(nil, false)
+	flags := NewFlags(WithHistorySize(2))
+	require.Equal(t, uint64(0), flags.Revision())
+
+	flags.Merge(log, "A", map[string]model.Flag{
+		"a": {DefaultVariant: "off"},
+		"b": {DefaultVariant: "off"},
+	})
+	require.Equal(t, uint64(1), flags.Revision())
+	revision, ok := flags.FlagRevision("a")
+	require.True(t, ok)
+	require.Equal(t, uint64(1), revision.Revision)
+	require.False(t, revision.UpdatedAt.IsZero())
+
+	// unchanged flags do not bump the revision
+	notifications, _ := flags.Merge(log, "A", map[string]model.Flag{
+		"a": {DefaultVariant: "off"},
+		"b": {DefaultVariant: "off"},
+	})
+	require.Empty(t, notifications)
+	require.Equal(t, uint64(1), flags.Revision())
+
+	flags.Update(log, "A", map[string]model.Flag{"a": {DefaultVariant: "on"}})
+	require.Equal(t, uint64(2), flags.Revision())
+	revision, _ = flags.FlagRevision("a")
+	require.Equal(t, uint64(2), revision.Revision)
+	revision, _ = flags.FlagRevision("b")
+	require.Equal(t, uint64(1), revision.Revision)
+
+	history := flags.History("a")
+	require.Len(t, history, 1)
+	require.Equal(t, "off", history[0].Flag.DefaultVariant)
+	require.Equal(t, uint64(1), history[0].Revision)
+	require.Equal(t, uint64(2), history[0].ReplacedAt)
+
+	notifications = flags.DeleteFlags(log, "A", map[string]model.Flag{"a": {}})
+	require.Equal(t, uint64(3), flags.Revision())
+	require.Equal(t, uint64(3), notifications["a"].(map[string]interface{})["revision"])
+	_, ok = flags.FlagRevision("a")
+	require.False(t, ok)
+	flags.DeleteFlags(log, "A", map[string]model.Flag{"b": {}})
+
+	// the history is bounded, evicting the oldest definitions
+	history = flags.History("")
+	require.Len(t, history, 2)
+	require.Equal(t, "on", history[0].Flag.DefaultVariant)
+	require.Equal(t, "b", history[1].Key)
+
+	state, err := flags.String()
+	require.NoError(t, err)
+	require.Contains(t, state, `"revision":4`)
+}
+
+func TestFlags_GetSelected(t *testing.T) {
+	log := logger.NewLogger(nil, false)
+	flags := NewFlags()
+	flags.FlagSources = []string{"A", "B", "C"}
+	flags.SourceMetadata = map[string]SourceDetails{
+		"A": {Source: "A", Selector: "app=a"},
+		"B": {Source: "B", Selector: "app=b"},
+	}
+
+	flags.Merge(log, "A", map[string]model.Flag{"shared": {DefaultVariant: "a"}, "a": {DefaultVariant: "a"}})
+	flags.Merge(log, "B", map[string]model.Flag{"shared": {DefaultVariant: "b"}})
+	flags.Add(log, "C", map[string]model.Flag{"c": {DefaultVariant: "c"}})
+
+	// flags shadowed by sources of higher priority are seen by selectors matching their source only
+	flag, ok := flags.GetSelected("shared", "app=a")
+	require.True(t, ok)
+	require.Equal(t, model.Flag{DefaultVariant: "a", Source: "A"}, flag)
+	flag, ok = flags.GetSelected("shared", "")
+	require.True(t, ok)
+	require.Equal(t, "b", flag.DefaultVariant)
+	_, ok = flags.GetSelected("a", "app=b")
+	require.False(t, ok)
+	flag, ok = flags.GetSelected("c", "C")
+	require.True(t, ok)
+	require.Equal(t, "c", flag.DefaultVariant)
+
+	require.Equal(t, map[string]model.Flag{
+		"shared": {DefaultVariant: "a", Source: "A"},
+		"a":      {DefaultVariant: "a", Source: "A"},
+	}, flags.GetAllSelected("app=a"))
+	require.Empty(t, flags.GetAllSelected("app=c"))
+	require.Equal(t, flags.GetAll(), flags.GetAllSelected(""))
+
+	// shadowed flags are updated and deleted along with their source
+	flags.Update(log, "A", map[string]model.Flag{"shared": {DefaultVariant: "a2"}})
+	flag, _ = flags.GetSelected("shared", "app=a")
+	require.Equal(t, "a2", flag.DefaultVariant)
+	flag, _ = flags.Get("shared")
+	require.Equal(t, "b", flag.DefaultVariant)
+	flags.DeleteFlags(log, "A", map[string]model.Flag{"shared": {}})
+	_, ok = flags.GetSelected("shared", "app=a")
+	require.False(t, ok)
+	flags.DeleteFlags(log, "A", nil)
+	require.Empty(t, flags.GetAllSelected("app=a"))
+}
+
+func TestFlags_DeleteShadowing(t *testing.T) {
+	log := logger.NewLogger(nil, false)
+	flags := NewFlags()
+	flags.FlagSources = []string{"A", "B"}
+
+	flags.Merge(log, "A", map[string]model.Flag{"shared": {DefaultVariant: "a"}})
+	flags.Merge(log, "B", map[string]model.Flag{"shared": {DefaultVariant: "b"}, "b": {DefaultVariant: "b"}})
+	flag, _ := flags.Get("shared")
+	require.Equal(t, "b", flag.DefaultVariant)
+
+	shadowed, ok := flags.Shadowed("shared", "B")
+	require.True(t, ok)
+	require.Equal(t, model.Flag{DefaultVariant: "a", Source: "A"}, shadowed)
+	_, ok = flags.Shadowed("b", "B")
+	require.False(t, ok)
+
+	// the flag of the source of lower priority is served again once the flag shadowing it is gone
+	notifications, _ := flags.Merge(log, "B", map[string]model.Flag{"b": {DefaultVariant: "b"}})
+	flag, ok = flags.Get("shared")
+	require.True(t, ok)
+	require.Equal(t, model.Flag{DefaultVariant: "a", Source: "A"}, flag)
+	require.Equal(t, string(model.NotificationUpdate), notifications["shared"].(map[string]interface{})["type"])
+	require.Equal(t, "A", notifications["shared"].(map[string]interface{})["source"])
+
+	flags.Merge(log, "B", map[string]model.Flag{"shared": {DefaultVariant: "b"}})
+	flags.DeleteFlags(log, "B", nil)
+	flag, ok = flags.Get("shared")
+	require.True(t, ok)
+	require.Equal(t, "a", flag.DefaultVariant)
+	_, ok = flags.Get("b")
+	require.False(t, ok)
+}
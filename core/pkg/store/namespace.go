@@ -0,0 +1,62 @@
+package store
+
+import (
+	"sort"
+)
+
+// Namespace returns the store of the namespace, creating it if needed. Each namespace holds its own flags, sources
+// and revisions, so that the priority of sources only applies among the sources of a namespace. The empty name refers
+// to the default namespace, which is the store itself.
+func (f *Flags) Namespace(name string) *Flags {
+	if name == "" || name == f.namespace {
+		return f
+	}
+
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	ns, ok := f.namespaces[name]
+	if !ok {
+		ns = NewFlags(WithHistorySize(f.historySize))
+		ns.namespace = name
+		if f.namespaces == nil {
+			f.namespaces = map[string]*Flags{}
+		}
+		f.namespaces[name] = ns
+	}
+
+	return ns
+}
+
+// LookupNamespace returns the store of the namespace, without creating it
+func (f *Flags) LookupNamespace(name string) (*Flags, bool) {
+	if name == "" || name == f.namespace {
+		return f, true
+	}
+
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	ns, ok := f.namespaces[name]
+
+	return ns, ok
+}
+
+// Namespaces returns the sorted names of the namespaces of the store, including the default namespace
+func (f *Flags) Namespaces() []string {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	names := []string{""}
+	for name := range f.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NamespaceOf returns the namespace the flags of the source are stored in
+func (f *Flags) NamespaceOf(source string) string {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	return f.SourceMetadata[source].Namespace
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlags_Namespace(t *testing.T) {
+	log := logger.NewLogger(nil, false)
+	flags := NewFlags(WithHistorySize(1))
+	flags.FlagSources = []string{"A", "B", "C"}
+	flags.SourceMetadata = map[string]SourceDetails{
+		"A": {Source: "A"},
+		"B": {Source: "B", Namespace: "team-b"},
+		"C": {Source: "C", Namespace: "team-b"},
+	}
+	teamB := flags.Namespace("team-b")
+	teamB.FlagSources = []string{"B", "C"}
+
+	require.Same(t, flags, flags.Namespace(""))
+	require.Same(t, teamB, flags.Namespace("team-b"))
+	require.Equal(t, "team-b", flags.NamespaceOf("C"))
+	require.Equal(t, []string{"", "team-b"}, flags.Namespaces())
+	_, ok := flags.LookupNamespace("team-c")
+	require.False(t, ok)
+	require.Equal(t, []string{"", "team-b"}, flags.Namespaces(), "lookups don't create namespaces")
+
+	// the same key is kept apart in each namespace, priority only applies within a namespace
+	flags.Merge(log, "A", map[string]model.Flag{"checkout": {DefaultVariant: "a"}})
+	notifications, _ := teamB.Merge(log, "C", map[string]model.Flag{"checkout": {DefaultVariant: "c"}})
+	require.Equal(t, "team-b", notifications["checkout"].(map[string]interface{})["namespace"])
+	teamB.Merge(log, "B", map[string]model.Flag{"checkout": {DefaultVariant: "b"}})
+
+	flag, ok := flags.Get("checkout")
+	require.True(t, ok)
+	require.Equal(t, model.Flag{DefaultVariant: "a", Source: "A"}, flag)
+	ns, ok := flags.LookupNamespace("team-b")
+	require.True(t, ok)
+	flag, ok = ns.Get("checkout")
+	require.True(t, ok)
+	require.Equal(t, model.Flag{DefaultVariant: "c", Source: "C"}, flag)
+
+	// revisions and history are kept per namespace
+	require.Equal(t, uint64(1), flags.Revision())
+	require.Equal(t, uint64(1), teamB.Revision())
+	teamB.Merge(log, "C", map[string]model.Flag{"checkout": {DefaultVariant: "c2"}})
+	require.Len(t, teamB.History("checkout"), 1)
+	require.Empty(t, flags.History("checkout"))
+
+	state, err := flags.String()
+	require.NoError(t, err)
+	require.Contains(t, state, `"namespaces":{"team-b":{"checkout":`)
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/model"
+)
+
+// NextTransition returns the earliest scheduled transition after the given time among the flags of all namespaces
+func (f *Flags) NextTransition(after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	for _, flags := range f.all() {
+		for _, flag := range flags.GetAll() {
+			for _, transition := range flag.Schedule.Transitions() {
+				if transition.After(after) && (!found || transition.Before(next)) {
+					next = transition
+					found = true
+				}
+			}
+		}
+	}
+
+	return next, found
+}
+
+// Transitions returns update notifications for the flags with a scheduled transition in the time range (from, to],
+// keyed by namespace and flag. Namespaces without transitions are left out.
+func (f *Flags) Transitions(from time.Time, to time.Time) map[string]map[string]interface{} {
+	notifications := map[string]map[string]interface{}{}
+	for _, flags := range f.all() {
+		revision := flags.Revision()
+		for key, flag := range flags.GetAll() {
+			for _, transition := range flag.Schedule.Transitions() {
+				if transition.After(from) && !transition.After(to) {
+					if notifications[flags.namespace] == nil {
+						notifications[flags.namespace] = map[string]interface{}{}
+					}
+					notifications[flags.namespace][key] = flags.notification(model.NotificationUpdate, flag.Source, revision)
+					break
+				}
+			}
+		}
+	}
+
+	return notifications
+}
+
+// all returns the store of each namespace
+func (f *Flags) all() []*Flags {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+	all := []*Flags{f}
+	for _, ns := range f.namespaces {
+		all = append(all, ns)
+	}
+
+	return all
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlags_Transitions(t *testing.T) {
+	log := logger.NewLogger(nil, false)
+	now := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	activate := now.Add(time.Hour)
+	deactivate := now.Add(2 * time.Hour)
+
+	flags := NewFlags()
+	flags.SourceMetadata["B"] = SourceDetails{Source: "B", Namespace: "team"}
+	flags.Merge(log, "A", map[string]model.Flag{
+		"a":      {Schedule: &model.Schedule{Activate: &activate}},
+		"static": {},
+	})
+	flags.Namespace("team").Merge(log, "B", map[string]model.Flag{
+		"a": {Schedule: &model.Schedule{Activate: &activate}},
+		"b": {Schedule: &model.Schedule{Deactivate: &deactivate}},
+	})
+
+	next, ok := flags.NextTransition(now)
+	require.True(t, ok)
+	require.Equal(t, activate, next)
+	next, ok = flags.NextTransition(activate)
+	require.True(t, ok)
+	require.Equal(t, deactivate, next)
+	_, ok = flags.NextTransition(deactivate)
+	require.False(t, ok)
+
+	// flags of the same key in several namespaces are notified in each of them
+	require.Equal(t, map[string]map[string]interface{}{
+		"": {
+			"a": map[string]interface{}{"type": "update", "source": "A", "revision": uint64(1)},
+		},
+		"team": {
+			"a": map[string]interface{}{"type": "update", "source": "B", "revision": uint64(1), "namespace": "team"},
+		},
+	}, flags.Transitions(now, activate))
+	require.Equal(t, map[string]map[string]interface{}{
+		"team": {
+			"b": map[string]interface{}{"type": "update", "source": "B", "revision": uint64(1), "namespace": "team"},
+		},
+	}, flags.Transitions(activate, deactivate))
+	require.Empty(t, flags.Transitions(deactivate, deactivate.Add(time.Hour)))
+}
+
+func TestFlags_Transitions_Ramp(t *testing.T) {
+	log := logger.NewLogger(nil, false)
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	end := start.Add(100 * time.Minute)
+
+	flags := NewFlags()
+	flags.Merge(log, "A", map[string]model.Flag{
+		"ramp": {Schedule: &model.Schedule{Ramp: &model.Ramp{Variant: "on", Start: start, End: end}}},
+	})
+
+	// the flag is notified at the start and at the end of its ramp only
+	next, ok := flags.NextTransition(start.Add(-time.Minute))
+	require.True(t, ok)
+	require.Equal(t, start, next)
+	next, ok = flags.NextTransition(start)
+	require.True(t, ok)
+	require.Equal(t, end, next)
+	require.Contains(t, flags.Transitions(end.Add(-time.Minute), end)[""], "ramp")
+	require.Empty(t, flags.Transitions(start, end.Add(-time.Second)))
+	_, ok = flags.NextTransition(end)
+	require.False(t, ok)
+}
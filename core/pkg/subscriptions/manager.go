@@ -78,7 +78,7 @@ func (s *Coordinator) FetchAllFlags(ctx context.Context, key interface{}, target
 		}
 		go func() {
 			s.logger.Debug(fmt.Sprintf("sync handler exists for target %s, triggering a resync", target))
-			if err := syncHandler.syncRef.ReSync(ctx, dataSyncChan); err != nil {
+			if err := syncHandler.resync(ctx, dataSyncChan); err != nil {
 				errChan <- err
 			}
 		}()
@@ -140,7 +140,7 @@ func (s *Coordinator) RegisterSubscription(
 				defer s.mu.RUnlock()
 				if _, ok := s.multiplexers[target]; ok {
 					s.logger.Debug(fmt.Sprintf("sync handler exists for target %s, triggering a resync", target))
-					if err := sh.syncRef.ReSync(ctx, dataSync); err != nil {
+					if err := sh.resync(ctx, dataSync); err != nil {
 						errChan <- err
 					}
 				}
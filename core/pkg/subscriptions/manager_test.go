@@ -10,6 +10,7 @@ import (
 
 	"github.com/open-feature/flagd/core/pkg/logger"
 	isync "github.com/open-feature/flagd/core/pkg/sync"
+	grpcsync "github.com/open-feature/flagd/core/pkg/sync/grpc"
 )
 
 type syncMock struct {
@@ -106,15 +107,29 @@ func Test_watchResource(t *testing.T) {
 		Source:   "im a flag source",
 		Type:     isync.ALL,
 	}
-	syncMock.dataSyncChanIn <- in
+	// the revision of the target is only bumped by changes of its flag configuration
+	revisions := []struct {
+		flagData string
+		revision uint64
+	}{
+		{flagData: "im a flag", revision: 1},
+		{flagData: "im a flag", revision: 1},
+		{flagData: "im another flag", revision: 2},
+	}
+	for _, r := range revisions {
+		in.FlagData = r.flagData
+		syncMock.dataSyncChanIn <- in
 
-	select {
-	case d := <-syncHandler.subs[key].dataSync:
-		if !reflect.DeepEqual(d, in) {
-			t.Error("unexpected sync data", in, d)
+		expected := in
+		expected.Metadata = map[string]interface{}{grpcsync.RevisionMetadataKey: r.revision}
+		select {
+		case d := <-syncHandler.subs[key].dataSync:
+			if !reflect.DeepEqual(d, expected) {
+				t.Error("unexpected sync data", expected, d)
+			}
+		case <-time.After(3 * time.Second):
+			t.Errorf("timed out waiting for broadcast of %v", in)
 		}
-	case <-time.After(3 * time.Second):
-		t.Errorf("timed out waiting for broadcast of %v", in)
 	}
 
 	// errors should be broadcasted to all registered sync subs
@@ -382,6 +397,9 @@ func Test_FetchAllFlags(t *testing.T) {
 			if tt.mockData != nil && !reflect.DeepEqual(data.FlagData, tt.mockData.FlagData) {
 				t.Error("data does not match expected value", tt.mockData.FlagData, data.FlagData)
 			}
+			if tt.mockData != nil && grpcsync.RevisionOf(data.Metadata) != 1 {
+				t.Error("data does not carry the revision of the target", data.Metadata)
+			}
 		})
 	}
 }
@@ -432,8 +450,10 @@ func Test_registerSubscriptionResyncPath(t *testing.T) {
 
 			select {
 			case d := <-dataChan:
-				if !reflect.DeepEqual(d, *tt.data) {
-					t.Error("received unexpected data", d, *tt.data)
+				expected := *tt.data
+				expected.Metadata = map[string]interface{}{grpcsync.RevisionMetadataKey: uint64(1)}
+				if !reflect.DeepEqual(d, expected) {
+					t.Error("received unexpected data", d, expected)
 				}
 			case err := <-errChan:
 				if !tt.expectErr {
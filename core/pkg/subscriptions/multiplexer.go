@@ -7,15 +7,60 @@ import (
 
 	"github.com/open-feature/flagd/core/pkg/logger"
 	sourceSync "github.com/open-feature/flagd/core/pkg/sync"
+	grpcsync "github.com/open-feature/flagd/core/pkg/sync/grpc"
 )
 
-// multiplexer distributes updates for a target to all of its subscribers
+// multiplexer distributes updates for a target to all of its subscribers. Flag configurations are stamped with the
+// revision of the target, bumped whenever its flag configuration changes.
 type multiplexer struct {
 	subs       map[interface{}]storedChannels
 	dataSync   chan sourceSync.DataSync
 	cancelFunc context.CancelFunc
 	syncRef    sourceSync.ISync
 	mu         *sync.RWMutex
+
+	revisionMu sync.Mutex
+	revision   uint64
+	flagData   string
+}
+
+// revise stamps the flag configuration with the revision of the target
+func (h *multiplexer) revise(data sourceSync.DataSync) sourceSync.DataSync {
+	h.revisionMu.Lock()
+	defer h.revisionMu.Unlock()
+
+	if h.revision == 0 || data.FlagData != h.flagData {
+		h.revision++
+		h.flagData = data.FlagData
+	}
+
+	metadata := make(map[string]interface{}, len(data.Metadata)+1)
+	for key, value := range data.Metadata {
+		metadata[key] = value
+	}
+	metadata[grpcsync.RevisionMetadataKey] = h.revision
+	data.Metadata = metadata
+
+	return data
+}
+
+// resync sends the full flag configuration of the target to a single subscriber
+func (h *multiplexer) resync(ctx context.Context, dataSync chan<- sourceSync.DataSync) error {
+	// sync providers send a single flag configuration on resync
+	resynced := make(chan sourceSync.DataSync, 1)
+	if err := h.syncRef.ReSync(ctx, resynced); err != nil {
+		return fmt.Errorf("error resyncing flag configuration: %w", err)
+	}
+
+	select {
+	case data := <-resynced:
+		select {
+		case dataSync <- h.revise(data):
+		case <-ctx.Done():
+		}
+	default:
+	}
+	return nil
 }
 
 func (h *multiplexer) broadcastError(logger *logger.Logger, err error) {
@@ -34,6 +79,7 @@ func (h *multiplexer) broadcastError(logger *logger.Logger, err error) {
 func (h *multiplexer) broadcastData(logger *logger.Logger, data sourceSync.DataSync) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
+	data = h.revise(data)
 	for k, ds := range h.subs {
 		select {
 		case ds.dataSync <- data:
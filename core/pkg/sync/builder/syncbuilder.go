@@ -10,11 +10,15 @@ import (
 
 	"github.com/open-feature/flagd/core/pkg/logger"
 	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/open-feature/flagd/core/pkg/sync/directory"
 	"github.com/open-feature/flagd/core/pkg/sync/file"
+	"github.com/open-feature/flagd/core/pkg/sync/git"
 	"github.com/open-feature/flagd/core/pkg/sync/grpc"
 	"github.com/open-feature/flagd/core/pkg/sync/grpc/credentials"
 	httpSync "github.com/open-feature/flagd/core/pkg/sync/http"
 	"github.com/open-feature/flagd/core/pkg/sync/kubernetes"
+	"github.com/open-feature/flagd/core/pkg/sync/s3"
+	"github.com/open-feature/flagd/core/pkg/sync/webhook"
 	"github.com/robfig/cron"
 	"go.uber.org/zap"
 	"k8s.io/client-go/dynamic"
@@ -24,9 +28,13 @@ import (
 
 const (
 	syncProviderFile       = "file"
+	syncProviderDirectory  = "directory"
+	syncProviderGit        = "git"
 	syncProviderGrpc       = "grpc"
 	syncProviderKubernetes = "kubernetes"
 	syncProviderHTTP       = "http"
+	syncProviderS3         = "s3"
+	syncProviderWebhook    = "webhook"
 )
 
 var (
@@ -35,6 +43,8 @@ var (
 	regGRPC       *regexp.Regexp
 	regGRPCSecure *regexp.Regexp
 	regFile       *regexp.Regexp
+	regDir        *regexp.Regexp
+	regS3         *regexp.Regexp
 )
 
 func init() {
@@ -43,6 +53,8 @@ func init() {
 	regGRPC = regexp.MustCompile("^" + grpc.Prefix)
 	regGRPCSecure = regexp.MustCompile("^" + grpc.PrefixSecure)
 	regFile = regexp.MustCompile("^file:")
+	regDir = regexp.MustCompile("^dir:")
+	regS3 = regexp.MustCompile("^" + s3.Prefix)
 }
 
 type ISyncBuilder interface {
@@ -65,6 +77,8 @@ func (sb *SyncBuilder) SyncFromURI(uri string, logger *logger.Logger) (sync.ISyn
 	// filepath may be used for debugging, not recommended in deployment
 	case regFile.Match(uriB):
 		return sb.newFile(uri, logger), nil
+	case regDir.Match(uriB):
+		return sb.newDirectory(sync.SourceConfig{URI: regDir.ReplaceAllString(uri, "")}, logger), nil
 	case regCrd.Match(uriB):
 		return sb.newK8s(uri, logger)
 	}
@@ -88,6 +102,12 @@ func (sb *SyncBuilder) syncFromConfig(sourceConfig sync.SourceConfig, logger *lo
 	case syncProviderFile:
 		logger.Debug(fmt.Sprintf("using filepath sync-provider for: %q", sourceConfig.URI))
 		return sb.newFile(sourceConfig.URI, logger), nil
+	case syncProviderDirectory:
+		logger.Debug(fmt.Sprintf("using directory sync-provider for: %q", sourceConfig.URI))
+		return sb.newDirectory(sourceConfig, logger), nil
+	case syncProviderGit:
+		logger.Debug(fmt.Sprintf("using git sync-provider for: %s", sourceConfig.URI))
+		return sb.newGit(sourceConfig, logger), nil
 	case syncProviderKubernetes:
 		logger.Debug(fmt.Sprintf("using kubernetes sync-provider for: %s", sourceConfig.URI))
 		return sb.newK8s(sourceConfig.URI, logger)
@@ -97,10 +117,18 @@ func (sb *SyncBuilder) syncFromConfig(sourceConfig sync.SourceConfig, logger *lo
 	case syncProviderGrpc:
 		logger.Debug(fmt.Sprintf("using grpc sync-provider for: %s", sourceConfig.URI))
 		return sb.newGRPC(sourceConfig, logger), nil
+	case syncProviderS3:
+		logger.Debug(fmt.Sprintf("using s3 sync-provider for: %s", sourceConfig.URI))
+		return sb.newS3(sourceConfig, logger), nil
+	case syncProviderWebhook:
+		logger.Debug(fmt.Sprintf("using webhook sync-provider for: %s", sourceConfig.URI))
+		return sb.newWebhook(sourceConfig, logger)
 
 	default:
-		return nil, fmt.Errorf("invalid sync provider: %s, must be one of with '%s', '%s', '%s' or '%s'",
-			sourceConfig.Provider, syncProviderFile, syncProviderKubernetes, syncProviderHTTP, syncProviderKubernetes)
+		return nil, fmt.Errorf(
+			"invalid sync provider: %s, must be one of with '%s', '%s', '%s', '%s', '%s', '%s', '%s' or '%s'",
+			sourceConfig.Provider, syncProviderFile, syncProviderDirectory, syncProviderGit, syncProviderKubernetes,
+			syncProviderHTTP, syncProviderGrpc, syncProviderS3, syncProviderWebhook)
 	}
 }
 
@@ -115,6 +143,49 @@ func (sb *SyncBuilder) newFile(uri string, logger *logger.Logger) *file.Sync {
 	}
 }
 
+func (sb *SyncBuilder) newDirectory(config sync.SourceConfig, logger *logger.Logger) *directory.Sync {
+	return &directory.Sync{
+		URI: config.URI,
+		Logger: logger.WithFields(
+			zap.String("component", "sync"),
+			zap.String("sync", "directory"),
+		),
+		Recursive: config.Recursive,
+		Include:   config.Include,
+		Exclude:   config.Exclude,
+		Debounce:  directory.DefaultDebounce,
+		Mux:       &msync.RWMutex{},
+	}
+}
+
+func (sb *SyncBuilder) newGit(config sync.SourceConfig, logger *logger.Logger) *git.Sync {
+	interval := git.DefaultInterval
+	if config.Interval != 0 {
+		interval = time.Duration(config.Interval) * time.Second
+	}
+	ref := git.DefaultRef
+	if config.Ref != "" {
+		ref = config.Ref
+	}
+	authHeader := config.AuthHeader
+	if authHeader == "" && config.BearerToken != "" {
+		authHeader = fmt.Sprintf("Bearer %s", config.BearerToken)
+	}
+
+	return &git.Sync{
+		URI:        config.URI,
+		Ref:        ref,
+		Paths:      config.Paths,
+		AuthHeader: authHeader,
+		Interval:   interval,
+		Logger: logger.WithFields(
+			zap.String("component", "sync"),
+			zap.String("sync", "git"),
+		),
+		Mux: &msync.RWMutex{},
+	}
+}
+
 func (sb *SyncBuilder) newK8s(uri string, logger *logger.Logger) (*kubernetes.Sync, error) {
 	dynamicClient, err := sb.k8sClientBuilder.GetK8sClient()
 	if err != nil {
@@ -169,6 +240,60 @@ func (sb *SyncBuilder) newGRPC(config sync.SourceConfig, logger *logger.Logger)
 	}
 }
 
+func (sb *SyncBuilder) newS3(config sync.SourceConfig, logger *logger.Logger) *s3.Sync {
+	interval := s3.DefaultInterval
+	if config.Interval != 0 {
+		interval = time.Duration(config.Interval) * time.Second
+	}
+
+	return &s3.Sync{
+		URI:      config.URI,
+		Endpoint: config.Endpoint,
+		Region:   config.Region,
+		Credentials: s3.Credentials{
+			AccessKeyID:     config.AccessKeyID,
+			SecretAccessKey: config.SecretAccessKey,
+		},
+		Interval: interval,
+		Client: &http.Client{
+			Timeout: time.Second * 10,
+		},
+		Logger: logger.WithFields(
+			zap.String("component", "sync"),
+			zap.String("sync", "s3"),
+		),
+	}
+}
+
+// newWebhook creates a webhook sync, along with its paired source built from the same configuration if any
+func (sb *SyncBuilder) newWebhook(config sync.SourceConfig, logger *logger.Logger) (*webhook.Sync, error) {
+	webhookSync := &webhook.Sync{
+		URI:    config.URI,
+		Listen: config.Listen,
+		Secret: config.Secret,
+		Logger: logger.WithFields(
+			zap.String("component", "sync"),
+			zap.String("sync", "webhook"),
+		),
+	}
+	if config.Paired == "" {
+		return webhookSync, nil
+	}
+	if config.Paired == syncProviderWebhook {
+		return nil, fmt.Errorf("invalid paired source of webhook %s: a webhook can't be paired with a webhook", config.URI)
+	}
+
+	pairedConfig := config
+	pairedConfig.Provider = config.Paired
+	pairedConfig.Paired = ""
+	paired, err := sb.syncFromConfig(pairedConfig, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid paired source of webhook %s: %w", config.URI, err)
+	}
+	webhookSync.Paired = paired
+	return webhookSync, nil
+}
+
 type IK8sClientBuilder interface {
 	GetK8sClient() (dynamic.Interface, error)
 }
@@ -8,10 +8,14 @@ import (
 	"github.com/open-feature/flagd/core/pkg/logger"
 	"github.com/open-feature/flagd/core/pkg/sync"
 	buildermock "github.com/open-feature/flagd/core/pkg/sync/builder/mock"
+	"github.com/open-feature/flagd/core/pkg/sync/directory"
 	"github.com/open-feature/flagd/core/pkg/sync/file"
+	"github.com/open-feature/flagd/core/pkg/sync/git"
 	"github.com/open-feature/flagd/core/pkg/sync/grpc"
 	"github.com/open-feature/flagd/core/pkg/sync/http"
 	"github.com/open-feature/flagd/core/pkg/sync/kubernetes"
+	"github.com/open-feature/flagd/core/pkg/sync/s3"
+	"github.com/open-feature/flagd/core/pkg/sync/webhook"
 	"github.com/stretchr/testify/require"
 )
 
@@ -70,6 +74,15 @@ func TestSyncBuilder_SyncFromURI(t *testing.T) {
 			want:    &file.Sync{},
 			wantErr: false,
 		},
+		{
+			name: "directory sync",
+			args: args{
+				uri:    "dir:my-flags",
+				logger: logger.NewLogger(nil, false),
+			},
+			want:    &directory.Sync{},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -211,6 +224,37 @@ func Test_SyncsFromFromConfig(t *testing.T) {
 						URI:      "my-namespace/my-flags",
 						Provider: syncProviderKubernetes,
 					},
+					{
+						URI:       "/tmp/flags",
+						Provider:  syncProviderDirectory,
+						Recursive: true,
+						Include:   []string{"*.json"},
+					},
+					{
+						URI:      "https://git.example.com/flags.git",
+						Provider: syncProviderGit,
+						Ref:      "main",
+						Paths:    []string{"flags.json"},
+					},
+					{
+						URI:      "s3://bucket/flags.json",
+						Provider: syncProviderS3,
+						Endpoint: "http://localhost:9000",
+					},
+					{
+						URI:      "pipeline",
+						Provider: syncProviderWebhook,
+						Listen:   ":8016",
+						Secret:   "secret",
+					},
+					{
+						URI:      "https://git.example.com/flags.git",
+						Provider: syncProviderWebhook,
+						Listen:   ":8017",
+						Secret:   "secret",
+						Paired:   syncProviderGit,
+						Paths:    []string{"flags.json"},
+					},
 				},
 			},
 			wantSyncs: []sync.ISync{
@@ -219,9 +263,31 @@ func Test_SyncsFromFromConfig(t *testing.T) {
 				&http.Sync{},
 				&file.Sync{},
 				&kubernetes.Sync{},
+				&directory.Sync{},
+				&git.Sync{},
+				&s3.Sync{},
+				&webhook.Sync{},
+				&webhook.Sync{},
 			},
 			wantErr: false,
 		},
+		{
+			name: "webhook paired with webhook",
+			args: args{
+				logger: lg,
+				sources: []sync.SourceConfig{
+					{
+						URI:      "pipeline",
+						Provider: syncProviderWebhook,
+						Listen:   ":8016",
+						Secret:   "secret",
+						Paired:   syncProviderWebhook,
+					},
+				},
+			},
+			wantSyncs: nil,
+			wantErr:   true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -240,6 +306,9 @@ func Test_SyncsFromFromConfig(t *testing.T) {
 			// check if we got the expected sync types
 			for index, wantType := range tt.wantSyncs {
 				require.IsType(t, wantType, syncs[index])
+				if webhookSync, ok := syncs[index].(*webhook.Sync); ok && tt.args.sources[index].Paired != "" {
+					require.IsType(t, &git.Sync{}, webhookSync.Paired)
+				}
 			}
 		})
 	}
@@ -43,6 +43,11 @@ func ParseSyncProviderURIs(uris []string) ([]sync.SourceConfig, error) {
 				URI:      regFile.ReplaceAllString(uri, ""),
 				Provider: syncProviderFile,
 			})
+		case regDir.Match(uriB):
+			syncProvidersParsed = append(syncProvidersParsed, sync.SourceConfig{
+				URI:      regDir.ReplaceAllString(uri, ""),
+				Provider: syncProviderDirectory,
+			})
 		case regCrd.Match(uriB):
 			syncProvidersParsed = append(syncProvidersParsed, sync.SourceConfig{
 				URI:      regCrd.ReplaceAllString(uri, ""),
@@ -53,6 +58,11 @@ func ParseSyncProviderURIs(uris []string) ([]sync.SourceConfig, error) {
 				URI:      uri,
 				Provider: syncProviderHTTP,
 			})
+		case regS3.Match(uriB):
+			syncProvidersParsed = append(syncProvidersParsed, sync.SourceConfig{
+				URI:      uri,
+				Provider: syncProviderS3,
+			})
 		case regGRPC.Match(uriB):
 			syncProvidersParsed = append(syncProvidersParsed, sync.SourceConfig{
 				URI:      regGRPC.ReplaceAllString(uri, ""),
@@ -65,8 +75,8 @@ func ParseSyncProviderURIs(uris []string) ([]sync.SourceConfig, error) {
 				TLS:      true,
 			})
 		default:
-			return syncProvidersParsed, fmt.Errorf("invalid sync uri argument: %s, must start with 'file:', "+
-				"'http(s)://', 'grpc(s)://', or 'core.openfeature.dev'", uri)
+			return syncProvidersParsed, fmt.Errorf("invalid sync uri argument: %s, must start with 'file:', 'dir:', "+
+				"'http(s)://', 'grpc(s)://', 's3://', or 'core.openfeature.dev'", uri)
 		}
 	}
 	return syncProvidersParsed, nil
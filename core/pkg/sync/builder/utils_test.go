@@ -61,7 +61,10 @@ func TestParseSource(t *testing.T) {
 				{"uri":"http://site.com","provider":"http","interval":77 },
 				{"uri":"default/my-flag-config","provider":"kubernetes"},
 				{"uri":"grpc-source:8080","provider":"grpc"},
-				{"uri":"my-flag-source:8080","provider":"grpc", "tls":true, "certPath": "/certs/ca.cert", "providerID": "flagd-weatherapp-sidecar", "selector": "source=database,app=weatherapp"}
+				{"uri":"my-flag-source:8080","provider":"grpc", "tls":true, "certPath": "/certs/ca.cert", "providerID": "flagd-weatherapp-sidecar", "selector": "source=database,app=weatherapp"},
+				{"uri":"/etc/flags","provider":"directory","recursive":true,"include":["*.json"],"exclude":["test/*"]},
+				{"uri":"git@example.com:flags.git","provider":"git","ref":"main","paths":["a.json","b.yaml"]},
+				{"uri":"s3://bucket/flags.json","provider":"s3","endpoint":"http://minio:9000","region":"eu-west-1","accessKeyId":"key","secretAccessKey":"secret"}
 			]`,
 			expectErr: false,
 			out: []sync.SourceConfig{
@@ -110,6 +113,27 @@ func TestParseSource(t *testing.T) {
 					ProviderID: "flagd-weatherapp-sidecar",
 					Selector:   "source=database,app=weatherapp",
 				},
+				{
+					URI:       "/etc/flags",
+					Provider:  syncProviderDirectory,
+					Recursive: true,
+					Include:   []string{"*.json"},
+					Exclude:   []string{"test/*"},
+				},
+				{
+					URI:      "git@example.com:flags.git",
+					Provider: syncProviderGit,
+					Ref:      "main",
+					Paths:    []string{"a.json", "b.yaml"},
+				},
+				{
+					URI:             "s3://bucket/flags.json",
+					Provider:        syncProviderS3,
+					Endpoint:        "http://minio:9000",
+					Region:          "eu-west-1",
+					AccessKeyID:     "key",
+					SecretAccessKey: "secret",
+				},
 			},
 		},
 		"multiple-auth-options": {
@@ -182,6 +206,8 @@ func TestParseSyncProviderURIs(t *testing.T) {
 				"grpc://host:port",
 				"grpcs://secure-grpc",
 				"core.openfeature.dev/default/my-crd",
+				"dir:my-flags",
+				"s3://bucket/flags.json",
 			},
 			expectErr: false,
 			out: []sync.SourceConfig{
@@ -207,6 +233,14 @@ func TestParseSyncProviderURIs(t *testing.T) {
 					URI:      "default/my-crd",
 					Provider: "kubernetes",
 				},
+				{
+					URI:      "my-flags",
+					Provider: "directory",
+				},
+				{
+					URI:      "s3://bucket/flags.json",
+					Provider: "s3",
+				},
 			},
 		},
 		"empty": {
@@ -0,0 +1,272 @@
+package directory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	msync "sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/open-feature/flagd/core/pkg/sync/file"
+)
+
+// DefaultDebounce is the time waited for further file events before a change of the directory is synced
+const DefaultDebounce = 250 * time.Millisecond
+
+// Sync watches a directory of flag configuration files (.json, .yaml and .yml) and merges them into a single flag
+// configuration. Hidden files and directories are ignored, which covers the data directories of mounted ConfigMaps.
+type Sync struct {
+	URI    string
+	Logger *logger.Logger
+	// Recursive watches the subdirectories as well
+	Recursive bool
+	// Include and Exclude are glob patterns, matched against the file name, or against the path relative to the
+	// directory if the pattern contains a separator. Without Include patterns, all flag configuration files are synced.
+	Include []string
+	Exclude []string
+	// Debounce is the time waited for further file events before a change is synced, DefaultDebounce if unset
+	Debounce time.Duration
+	watcher  *fsnotify.Watcher
+	ready    bool
+	Mux      *msync.RWMutex
+}
+
+func NewDirectorySync(uri string, logger *logger.Logger) *Sync {
+	return &Sync{
+		URI:      uri,
+		Logger:   logger,
+		Debounce: DefaultDebounce,
+		Mux:      &msync.RWMutex{},
+	}
+}
+
+func (ds *Sync) Init(_ context.Context) error {
+	ds.Logger.Info("Starting directory sync notifier")
+	for _, pattern := range append(append([]string{}, ds.Include...), ds.Exclude...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+	if info, err := os.Stat(ds.URI); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", ds.URI)
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error creating directory watcher: %w", err)
+	}
+	ds.watcher = w
+	if err := ds.watch(ds.URI); err != nil {
+		_ = ds.watcher.Close()
+		return err
+	}
+	return nil
+}
+
+func (ds *Sync) IsReady() bool {
+	ds.Mux.RLock()
+	defer ds.Mux.RUnlock()
+	return ds.ready
+}
+
+func (ds *Sync) setReady(val bool) {
+	ds.Mux.Lock()
+	defer ds.Mux.Unlock()
+	ds.ready = val
+}
+
+func (ds *Sync) ReSync(_ context.Context, dataSync chan<- sync.DataSync) error {
+	if err := ds.sendDataSync(dataSync); err != nil {
+		// the last merged flag configuration of the directory is kept
+		ds.Logger.Error(err.Error())
+	}
+	return nil
+}
+
+func (ds *Sync) Sync(ctx context.Context, dataSync chan<- sync.DataSync) error {
+	defer ds.watcher.Close()
+	if err := ds.sendDataSync(dataSync); err != nil {
+		return err
+	}
+	ds.setReady(true)
+	ds.Logger.Info(fmt.Sprintf("watching directory: %s", ds.URI))
+
+	debounce := ds.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+	// pending fires once no file event has been received for the debounce time
+	var pending <-chan time.Time
+	for {
+		select {
+		case event, ok := <-ds.watcher.Events:
+			if !ok {
+				ds.Logger.Info("directory notifier closed")
+				return errors.New("directory notifier closed")
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			ds.Logger.Debug(fmt.Sprintf("directory event: %s %s", event.Name, event.Op.String()))
+			if event.Has(fsnotify.Create) && ds.Recursive {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() && !hidden(event.Name) {
+					if err := ds.watch(event.Name); err != nil {
+						ds.Logger.Error(err.Error())
+					}
+				}
+			}
+			pending = time.After(debounce)
+		case <-pending:
+			pending = nil
+			if err := ds.sendDataSync(dataSync); err != nil {
+				// the last merged flag configuration of the directory is kept
+				ds.Logger.Error(err.Error())
+			}
+		case err, ok := <-ds.watcher.Errors:
+			if !ok {
+				ds.setReady(false)
+				return errors.New("watcher error")
+			}
+
+			ds.Logger.Error(err.Error())
+		case <-ctx.Done():
+			ds.Logger.Debug("exiting directory watcher")
+			return nil
+		}
+	}
+}
+
+// watch adds the directory, and its subdirectories if the sync is recursive, to the watcher
+func (ds *Sync) watch(dir string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && (!ds.Recursive || hidden(path)) {
+			return filepath.SkipDir
+		}
+		if err := ds.watcher.Add(path); err != nil {
+			return fmt.Errorf("error adding watcher %s: %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error watching directory %s: %w", dir, err)
+	}
+	return nil
+}
+
+func (ds *Sync) sendDataSync(dataSync chan<- sync.DataSync) error {
+	msg, err := ds.Fetch()
+	if err != nil {
+		return err
+	}
+
+	ds.Logger.Debug(fmt.Sprintf("Configuration %s:  %s", ds.URI, sync.ALL.String()))
+	dataSync <- sync.DataSync{FlagData: msg, Source: ds.URI, Type: sync.ALL}
+	return nil
+}
+
+// Fetch reads the flag configuration files of the directory, in lexical order, and returns their merged content as
+// JSON. A flag key (or any key of other object properties, such as $evaluators) defined by several files is an error.
+func (ds *Sync) Fetch() (string, error) {
+	if ds.URI == "" {
+		return "", errors.New("no directory set")
+	}
+
+	merger := file.NewMerger()
+	err := filepath.WalkDir(ds.URI, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == ds.URI {
+			return nil
+		}
+		if d.IsDir() {
+			if !ds.Recursive || hidden(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !ds.matches(path) {
+			return nil
+		}
+
+		rawFile, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading file %s: %w", path, err)
+		}
+		return merger.Add(ds.rel(path), rawFile)
+	})
+	if err != nil {
+		return "", fmt.Errorf("error reading directory %s: %w", ds.URI, err)
+	}
+
+	flagData, err := merger.String()
+	if err != nil {
+		return "", fmt.Errorf("error merging directory %s: %w", ds.URI, err)
+	}
+	return flagData, nil
+}
+
+// matches checks whether the file is a flag configuration file selected by the include and exclude patterns
+func (ds *Sync) matches(path string) bool {
+	if hidden(path) {
+		return false
+	}
+	switch filepath.Ext(path) {
+	case ".json", ".yaml", ".yml":
+	default:
+		return false
+	}
+
+	rel := ds.rel(path)
+	for _, pattern := range ds.Exclude {
+		if match(pattern, rel) {
+			return false
+		}
+	}
+	if len(ds.Include) == 0 {
+		return true
+	}
+	for _, pattern := range ds.Include {
+		if match(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// rel returns the slash separated path of the file relative to the directory
+func (ds *Sync) rel(path string) string {
+	rel, err := filepath.Rel(ds.URI, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// match matches the glob pattern against the file name, or against the relative path if it contains a separator
+func match(pattern string, rel string) bool {
+	name := rel
+	if !strings.Contains(pattern, "/") {
+		name = filepath.Base(rel)
+	}
+	ok, _ := filepath.Match(pattern, name)
+	return ok
+}
+
+func hidden(path string) bool {
+	return strings.HasPrefix(filepath.Base(path), ".")
+}
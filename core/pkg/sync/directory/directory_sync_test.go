@@ -0,0 +1,175 @@
+package directory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetch(t *testing.T) {
+	tests := map[string]struct {
+		files     map[string]string
+		recursive bool
+		include   []string
+		exclude   []string
+		want      string
+		wantErr   bool
+	}{
+		"merged files": {
+			files: map[string]string{
+				"a.json":  `{"$schema":"https://flagd.dev/schema/v0/flags.json","flags":{"a":{"state":"ENABLED"}}}`,
+				"b.yaml":  "flags:\n  b:\n    state: DISABLED\n$evaluators:\n  x: true\n",
+				"c.yml":   "",
+				"d.txt":   `{"flags":{"d":{}}}`,
+				".e.json": `{"flags":{"e":{}}}`,
+			},
+			want: `{"$schema":"https://flagd.dev/schema/v0/flags.json","$evaluators":{"x":true},` +
+				`"flags":{"a":{"state":"ENABLED"},"b":{"state":"DISABLED"}}}`,
+		},
+		"subdirectories are ignored": {
+			files: map[string]string{
+				"a.json":      `{"flags":{"a":{}}}`,
+				"team/b.json": `{"flags":{"b":{}}}`,
+			},
+			want: `{"flags":{"a":{}}}`,
+		},
+		"recursive": {
+			files: map[string]string{
+				"a.json":            `{"flags":{"a":{}}}`,
+				"team/b.json":       `{"flags":{"b":{}}}`,
+				"..data/c.json":     `{"flags":{"c":{}}}`,
+				"team/sub/c.yaml":   `flags: {c: {}}`,
+				"team/sub/d.json":   `{"flags":{"d":{}}}`,
+				"other/e_test.json": `{"flags":{"e":{}}}`,
+			},
+			recursive: true,
+			include:   []string{"team/*/*", "a.json", "*_test.json"},
+			exclude:   []string{"d.json", "other/*"},
+			want:      `{"flags":{"a":{},"c":{}}}`,
+		},
+		"duplicate flag": {
+			files: map[string]string{
+				"a.json":      `{"flags":{"a":{}}}`,
+				"team/b.json": `{"flags":{"a":{}}}`,
+			},
+			recursive: true,
+			wantErr:   true,
+		},
+		"invalid file": {
+			files: map[string]string{
+				"a.json": `{"flags":`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for file, content := range tt.files {
+				writeFile(t, filepath.Join(dir, file), content)
+			}
+
+			ds := Sync{
+				URI:       dir,
+				Logger:    logger.NewLogger(nil, false),
+				Recursive: tt.recursive,
+				Include:   tt.include,
+				Exclude:   tt.exclude,
+			}
+			flagData, err := ds.Fetch()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, flagData)
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.json"), `{"flags":{"a":{}}}`)
+
+	ds := NewDirectorySync(dir, logger.NewLogger(nil, false))
+	ds.Recursive = true
+	ds.Debounce = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, ds.Init(ctx))
+	require.False(t, ds.IsReady())
+
+	dataSync := make(chan sync.DataSync, 10)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- ds.Sync(ctx, dataSync)
+	}()
+
+	require.JSONEq(t, `{"flags":{"a":{}}}`, receive(t, dataSync).FlagData)
+	require.Eventually(t, ds.IsReady, time.Second, 10*time.Millisecond)
+
+	// changes are debounced into a single sync, including files of new subdirectories
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "team"), 0o755))
+	time.Sleep(10 * time.Millisecond)
+	writeFile(t, filepath.Join(dir, "team", "b.yaml"), `flags: {b: {}}`)
+	writeFile(t, filepath.Join(dir, "c.json"), `{"flags":{"c":{}}}`)
+	data := receive(t, dataSync)
+	require.Equal(t, dir, data.Source)
+	require.Equal(t, sync.ALL, data.Type)
+	require.JSONEq(t, `{"flags":{"a":{},"b":{},"c":{}}}`, data.FlagData)
+
+	// invalid changes are not synced
+	writeFile(t, filepath.Join(dir, "d.json"), `{"flags":{"a":{}}}`)
+	select {
+	case data := <-dataSync:
+		t.Fatalf("unexpected sync of duplicate flag: %s", data.FlagData)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "d.json")))
+	require.NoError(t, os.Remove(filepath.Join(dir, "a.json")))
+	require.JSONEq(t, `{"flags":{"b":{},"c":{}}}`, receive(t, dataSync).FlagData)
+
+	require.NoError(t, ds.ReSync(ctx, dataSync))
+	require.JSONEq(t, `{"flags":{"b":{},"c":{}}}`, receive(t, dataSync).FlagData)
+
+	cancel()
+	require.NoError(t, <-errs)
+}
+
+func TestInit(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.json")
+	writeFile(t, file, `{}`)
+
+	require.Error(t, NewDirectorySync(file, logger.NewLogger(nil, false)).Init(context.Background()))
+
+	ds := NewDirectorySync(dir, logger.NewLogger(nil, false))
+	ds.Include = []string{"["}
+	require.Error(t, ds.Init(context.Background()))
+}
+
+func receive(t *testing.T, dataSync <-chan sync.DataSync) sync.DataSync {
+	t.Helper()
+	select {
+	case data := <-dataSync:
+		return data
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for datasync")
+	}
+	return sync.DataSync{}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
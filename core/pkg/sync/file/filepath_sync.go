@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"mime"
 	"os"
 	"strings"
 	msync "sync"
@@ -132,7 +133,7 @@ func (fs *Sync) sendDataSync(ctx context.Context, syncType sync.Type, dataSync c
 	}
 
 	msg := defaultState
-	m, err := fs.fetch(ctx)
+	m, err := fs.Fetch(ctx)
 	if err != nil {
 		fs.Logger.Error(fmt.Sprintf("Error fetching %s: %s", fs.URI, err.Error()))
 	}
@@ -145,7 +146,8 @@ func (fs *Sync) sendDataSync(ctx context.Context, syncType sync.Type, dataSync c
 	dataSync <- sync.DataSync{FlagData: msg, Source: fs.URI, Type: syncType}
 }
 
-func (fs *Sync) fetch(_ context.Context) (string, error) {
+// Fetch reads the flag configuration file and returns its content as JSON, converting YAML files if needed
+func (fs *Sync) Fetch(_ context.Context) (string, error) {
 	if fs.URI == "" {
 		return "", errors.New("no filepath string set")
 	}
@@ -160,7 +162,7 @@ func (fs *Sync) fetch(_ context.Context) (string, error) {
 
 	switch fs.fileType {
 	case "yaml", "yml":
-		return yamlToJSON(rawFile)
+		return YAMLToJSON(rawFile)
 	case "json":
 		return string(rawFile), nil
 	default:
@@ -168,9 +170,9 @@ func (fs *Sync) fetch(_ context.Context) (string, error) {
 	}
 }
 
-// yamlToJSON is a generic helper function to convert
+// YAMLToJSON is a generic helper function to convert
 // yaml to json
-func yamlToJSON(rawFile []byte) (string, error) {
+func YAMLToJSON(rawFile []byte) (string, error) {
 	var ms map[string]interface{}
 	// yaml.Unmarshal unmarshals to map[interface]interface{}
 	if err := yaml.Unmarshal(rawFile, &ms); err != nil {
@@ -184,3 +186,17 @@ func yamlToJSON(rawFile []byte) (string, error) {
 
 	return string(r), err
 }
+
+// IsYAMLContentType checks whether a Content-Type header denotes YAML
+func IsYAMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return true
+	default:
+		return false
+	}
+}
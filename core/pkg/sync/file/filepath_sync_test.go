@@ -231,7 +231,7 @@ func TestFilePathSync_Fetch(t *testing.T) {
 			createFile(t, tt.fetchDirName)
 			writeToFile(t, tt.fetchDirName, fetchFileContents)
 
-			data, err := tt.fpSync.fetch(context.Background())
+			data, err := tt.fpSync.Fetch(context.Background())
 
 			tt.handleResponse(t, data, err)
 		})
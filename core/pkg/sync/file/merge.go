@@ -0,0 +1,113 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	flagsProperty    = "flags"
+	metadataProperty = "metadata"
+)
+
+// Merger merges several flag configuration files into a single flag configuration. Flags and other object properties
+// (such as $evaluators) are merged by key, a key defined by several files is an error. Other properties are taken from
+// the first file defining them. The flag set metadata of each file only applies to the flags of the file, it is
+// merged into their metadata and left out of the merged flag configuration.
+type Merger struct {
+	merged map[string]interface{}
+	// definedIn tracks the file defining each key of the object properties, by property
+	definedIn map[string]map[string]string
+}
+
+func NewMerger() *Merger {
+	return &Merger{
+		merged:    map[string]interface{}{},
+		definedIn: map[string]map[string]string{},
+	}
+}
+
+// Add merges the content of the named file, which is parsed as YAML or JSON depending on its extension. Empty files
+// are skipped.
+func (m *Merger) Add(name string, rawFile []byte) error {
+	config := map[string]interface{}{}
+	if len(strings.TrimSpace(string(rawFile))) == 0 {
+		return nil
+	}
+
+	var err error
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(rawFile, &config)
+	case ".json":
+		err = json.Unmarshal(rawFile, &config)
+	default:
+		return fmt.Errorf("file extension of %s is not supported", name)
+	}
+	if err != nil {
+		return fmt.Errorf("error parsing file %s: %w", name, err)
+	}
+
+	applySetMetadata(config)
+	for property, value := range config {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			if _, defined := m.merged[property]; !defined {
+				m.merged[property] = value
+			}
+			continue
+		}
+
+		target, ok := m.merged[property].(map[string]interface{})
+		if !ok {
+			target = map[string]interface{}{}
+			m.merged[property] = target
+			m.definedIn[property] = map[string]string{}
+		}
+		for key, v := range object {
+			if other, defined := m.definedIn[property][key]; defined {
+				return fmt.Errorf("duplicate key '%s' of '%s' in %s and %s", key, property, other, name)
+			}
+			target[key] = v
+			m.definedIn[property][key] = name
+		}
+	}
+	return nil
+}
+
+// applySetMetadata merges the flag set metadata of the configuration into the metadata of each of its flags, entries
+// of the flags taking precedence, and removes it from the configuration
+func applySetMetadata(config map[string]interface{}) {
+	setMetadata, ok := config[metadataProperty].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(config, metadataProperty)
+
+	flags, _ := config[flagsProperty].(map[string]interface{})
+	for _, f := range flags {
+		flag, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		metadata := maps.Clone(setMetadata)
+		if flagMetadata, ok := flag[metadataProperty].(map[string]interface{}); ok {
+			maps.Copy(metadata, flagMetadata)
+		}
+		flag[metadataProperty] = metadata
+	}
+}
+
+// String returns the merged flag configuration as JSON
+func (m *Merger) String() (string, error) {
+	data, err := json.Marshal(m.merged)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling merged flag configuration: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,63 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerger(t *testing.T) {
+	tests := map[string]struct {
+		files   map[string]string
+		want    string
+		wantErr string
+	}{
+		"flags and evaluators": {
+			files: map[string]string{
+				"a.json": `{"flags":{"a":{"state":"ENABLED"}},"$evaluators":{"x":{}}}`,
+				"b.yaml": "flags:\n  b:\n    state: DISABLED\n",
+			},
+			want: `{"flags":{"a":{"state":"ENABLED"},"b":{"state":"DISABLED"}},"$evaluators":{"x":{}}}`,
+		},
+		"duplicate flag": {
+			files: map[string]string{
+				"a.json": `{"flags":{"a":{}}}`,
+				"b.json": `{"flags":{"a":{}}}`,
+			},
+			wantErr: "duplicate key 'a' of 'flags' in a.json and b.json",
+		},
+		"flag set metadata applies to the flags of its file": {
+			files: map[string]string{
+				"a.json": `{"metadata":{"owner":"team-a","tier":1},"flags":{"a":{"metadata":{"tier":2}}}}`,
+				"b.json": `{"metadata":{"owner":"team-b"},"flags":{"b":{}}}`,
+				"c.json": `{"flags":{"c":{}}}`,
+			},
+			want: `{"flags":{
+				"a":{"metadata":{"owner":"team-a","tier":2}},
+				"b":{"metadata":{"owner":"team-b"}},
+				"c":{}
+			}}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			merger := NewMerger()
+			var err error
+			for _, file := range []string{"a.json", "b.json", "b.yaml", "c.json"} {
+				if content, ok := tt.files[file]; ok && err == nil {
+					err = merger.Add(file, []byte(content))
+				}
+			}
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			merged, err := merger.String()
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, merged)
+		})
+	}
+}
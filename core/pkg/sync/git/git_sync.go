@@ -0,0 +1,292 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	msync "sync"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/open-feature/flagd/core/pkg/sync/file"
+)
+
+const (
+	// CommitMetadataKey holds the commit the flag configuration was read from, in the metadata of the source and of
+	// the evaluations of its flags
+	CommitMetadataKey = "commit"
+	// DefaultRef is the ref synced if none is configured, the default branch of the repository
+	DefaultRef = "HEAD"
+	// DefaultInterval is the polling interval used if none is configured
+	DefaultInterval = 30 * time.Second
+)
+
+// Sync polls a branch or tag of a git repository and reads flag configuration files of the commit it points to. The
+// repository can be a local path, or a file://, ssh or https URL. The git executable is required, credentials of ssh
+// repositories are taken from the environment (ssh agent, keys and known hosts).
+type Sync struct {
+	URI string
+	// Ref is the branch or tag to sync, DefaultRef if unset
+	Ref string
+	// Paths are the flag configuration files (.json, .yaml or .yml) in the repository, merged into a single flag
+	// configuration. A flag key defined by several files is an error.
+	Paths []string
+	// AuthHeader is sent as Authorization header to https repositories
+	AuthHeader string
+	// Interval is the polling interval, DefaultInterval if unset
+	Interval time.Duration
+	Logger   *logger.Logger
+	Mux      *msync.RWMutex
+
+	// gitMx serializes the git commands on the local repository, and guards the fields below
+	gitMx  msync.Mutex
+	dir    string
+	commit string
+	// synced is the last commit whose flag configuration was sent
+	synced string
+	ready  bool
+}
+
+func NewGitSync(uri string, paths []string, logger *logger.Logger) *Sync {
+	return &Sync{
+		URI:      uri,
+		Ref:      DefaultRef,
+		Paths:    paths,
+		Interval: DefaultInterval,
+		Logger:   logger,
+		Mux:      &msync.RWMutex{},
+	}
+}
+
+// Init creates the local repository the commits are fetched into, the repository of a previous Init is reused until
+// it is closed
+func (gs *Sync) Init(ctx context.Context) error {
+	if len(gs.Paths) == 0 {
+		return fmt.Errorf("no flag configuration file set for git repository %s", gs.URI)
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git executable is required to sync %s: %w", gs.URI, err)
+	}
+
+	gs.gitMx.Lock()
+	defer gs.gitMx.Unlock()
+	if gs.dir != "" {
+		return nil
+	}
+	dir, err := os.MkdirTemp("", "flagd-git-")
+	if err != nil {
+		return fmt.Errorf("error creating local repository: %w", err)
+	}
+	gs.dir = dir
+	if _, err := gs.git(ctx, "init", "--quiet", "--bare"); err != nil {
+		_ = gs.closeLocked()
+		return err
+	}
+	return nil
+}
+
+// Close removes the local repository, which is created again by the next Init. Sync closes it once it returns, Close
+// is meant for callers not running Sync, or stopping it without waiting for it to return.
+func (gs *Sync) Close() error {
+	gs.gitMx.Lock()
+	defer gs.gitMx.Unlock()
+	return gs.closeLocked()
+}
+
+// closeLocked removes the local repository, gitMx must be held
+func (gs *Sync) closeLocked() error {
+	if gs.dir == "" {
+		return nil
+	}
+	dir := gs.dir
+	gs.dir = ""
+	gs.commit = ""
+	gs.synced = ""
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("error removing local repository of %s: %w", gs.URI, err)
+	}
+	return nil
+}
+
+func (gs *Sync) IsReady() bool {
+	gs.Mux.RLock()
+	defer gs.Mux.RUnlock()
+	return gs.ready
+}
+
+func (gs *Sync) setReady(val bool) {
+	gs.Mux.Lock()
+	defer gs.Mux.Unlock()
+	gs.ready = val
+}
+
+// ReSync fetches the configured ref and sends the flag configuration of the commit it points to. If the fetch fails,
+// the flag configuration of the last fetched commit is read again.
+func (gs *Sync) ReSync(ctx context.Context, dataSync chan<- sync.DataSync) error {
+	flagData, commit, err := gs.Fetch(ctx)
+	if err != nil {
+		gs.Logger.Warn(fmt.Sprintf("resyncing last fetched commit: %s", err.Error()))
+		gs.gitMx.Lock()
+		commit = gs.commit
+		gs.gitMx.Unlock()
+		if commit == "" {
+			return nil
+		}
+		if flagData, err = gs.read(ctx, commit); err != nil {
+			return err
+		}
+	}
+	gs.markSynced(commit)
+	dataSync <- gs.dataSync(flagData, commit)
+	return nil
+}
+
+// Sync sends the flag configuration of the configured ref, and polls it for new commits until the context ends. The
+// local repository is closed once Sync returns, be it on error.
+func (gs *Sync) Sync(ctx context.Context, dataSync chan<- sync.DataSync) error {
+	defer func() {
+		if err := gs.Close(); err != nil {
+			gs.Logger.Warn(err.Error())
+		}
+	}()
+
+	flagData, commit, err := gs.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+	gs.markSynced(commit)
+	dataSync <- gs.dataSync(flagData, commit)
+	gs.setReady(true)
+
+	interval := gs.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	gs.Logger.Debug(fmt.Sprintf("polling %s every %s", gs.URI, interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			flagData, commit, err := gs.Fetch(ctx)
+			if err != nil {
+				// the flag configuration of the last synced commit is kept
+				gs.Logger.Error(err.Error())
+				continue
+			}
+			if gs.markSynced(commit) {
+				gs.Logger.Info(fmt.Sprintf("syncing commit %s of %s", commit, gs.URI))
+				dataSync <- gs.dataSync(flagData, commit)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Fetch fetches the configured ref and returns the flag configuration of the commit it points to, along with the
+// commit
+func (gs *Sync) Fetch(ctx context.Context) (string, string, error) {
+	ref := gs.Ref
+	if ref == "" {
+		ref = DefaultRef
+	}
+
+	gs.gitMx.Lock()
+	defer gs.gitMx.Unlock()
+	if _, err := gs.git(ctx, "fetch", "--quiet", "--depth=1", "--no-tags", gs.URI, ref); err != nil {
+		return "", "", err
+	}
+	out, err := gs.git(ctx, "rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return "", "", err
+	}
+	commit := strings.TrimSpace(string(out))
+
+	flagData, err := gs.readLocked(ctx, commit)
+	if err != nil {
+		return "", "", err
+	}
+	gs.commit = commit
+	return flagData, commit, nil
+}
+
+// markSynced records the commit whose flag configuration is sent, and returns false if it was already sent
+func (gs *Sync) markSynced(commit string) bool {
+	gs.gitMx.Lock()
+	defer gs.gitMx.Unlock()
+	if gs.synced == commit {
+		return false
+	}
+	gs.synced = commit
+	return true
+}
+
+func (gs *Sync) read(ctx context.Context, commit string) (string, error) {
+	gs.gitMx.Lock()
+	defer gs.gitMx.Unlock()
+	return gs.readLocked(ctx, commit)
+}
+
+// readLocked merges the flag configuration files of the commit, gitMx must be held
+func (gs *Sync) readLocked(ctx context.Context, commit string) (string, error) {
+	merger := file.NewMerger()
+	for _, path := range gs.Paths {
+		rawFile, err := gs.git(ctx, "show", fmt.Sprintf("%s:%s", commit, strings.TrimPrefix(path, "/")))
+		if err != nil {
+			return "", err
+		}
+		if err := merger.Add(path, rawFile); err != nil {
+			return "", fmt.Errorf("error reading commit %s of %s: %w", commit, gs.URI, err)
+		}
+	}
+
+	flagData, err := merger.String()
+	if err != nil {
+		return "", fmt.Errorf("error reading commit %s of %s: %w", commit, gs.URI, err)
+	}
+	return flagData, nil
+}
+
+func (gs *Sync) dataSync(flagData string, commit string) sync.DataSync {
+	return sync.DataSync{
+		FlagData: flagData,
+		Source:   gs.URI,
+		Type:     sync.ALL,
+		Metadata: map[string]interface{}{CommitMetadataKey: commit},
+	}
+}
+
+// git runs a git command on the local repository. The Authorization header is passed through the environment, so
+// that it does not show up in the command line.
+func (gs *Sync) git(ctx context.Context, args ...string) ([]byte, error) {
+	if gs.dir == "" {
+		return nil, fmt.Errorf("local repository of %s is not initialized", gs.URI)
+	}
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", gs.dir}, args...)...) //nolint:gosec
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if gs.AuthHeader != "" {
+		cmd.Env = append(cmd.Env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: "+gs.AuthHeader,
+		)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("git %s of %s failed: %s", args[0], gs.URI, strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("error running git %s of %s: %w", args[0], gs.URI, err)
+	}
+	return out, nil
+}
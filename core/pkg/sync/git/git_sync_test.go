@@ -0,0 +1,190 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	repo := newRepository(t)
+	commit := repo.commit(t, map[string]string{
+		"flags/a.json": `{"flags":{"a":{"state":"ENABLED"}}}`,
+		"flags/b.yaml": "flags:\n  b:\n    state: DISABLED\n",
+	})
+
+	gs := NewGitSync("file://"+repo.dir, []string{"flags/a.json", "/flags/b.yaml"}, logger.NewLogger(nil, false))
+	gs.Ref = "main"
+	gs.Interval = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, gs.Init(ctx))
+	require.False(t, gs.IsReady())
+
+	dataSync := make(chan sync.DataSync, 10)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- gs.Sync(ctx, dataSync)
+	}()
+
+	data := receive(t, dataSync)
+	require.Equal(t, "file://"+repo.dir, data.Source)
+	require.Equal(t, sync.ALL, data.Type)
+	require.Equal(t, map[string]interface{}{CommitMetadataKey: commit}, data.Metadata)
+	require.JSONEq(t, `{"flags":{"a":{"state":"ENABLED"},"b":{"state":"DISABLED"}}}`, data.FlagData)
+	require.True(t, gs.IsReady())
+
+	// unchanged commits are not synced again
+	select {
+	case data := <-dataSync:
+		t.Fatalf("unexpected sync of unchanged commit: %v", data)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	commit = repo.commit(t, map[string]string{"flags/b.yaml": "flags:\n  b:\n    state: ENABLED\n"})
+	data = receive(t, dataSync)
+	require.Equal(t, commit, data.Metadata[CommitMetadataKey])
+	require.JSONEq(t, `{"flags":{"a":{"state":"ENABLED"},"b":{"state":"ENABLED"}}}`, data.FlagData)
+
+	// invalid commits are not synced
+	repo.commit(t, map[string]string{"flags/b.yaml": "flags:\n  a:\n    state: ENABLED\n"})
+	select {
+	case data := <-dataSync:
+		t.Fatalf("unexpected sync of duplicate flag: %v", data)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	cancel()
+	require.NoError(t, <-errs)
+}
+
+func TestFetch(t *testing.T) {
+	repo := newRepository(t)
+	first := repo.commit(t, map[string]string{"flags.json": `{"flags":{"a":{}}}`})
+	repo.git(t, "tag", "v1")
+	repo.commit(t, map[string]string{"flags.json": `{"flags":{"b":{}}}`})
+
+	ctx := context.Background()
+	gs := NewGitSync(repo.dir, []string{"flags.json"}, logger.NewLogger(nil, false))
+	gs.Ref = "v1"
+	require.NoError(t, gs.Init(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, gs.Close())
+	})
+
+	flagData, commit, err := gs.Fetch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, first, commit)
+	require.JSONEq(t, `{"flags":{"a":{}}}`, flagData)
+
+	dataSync := make(chan sync.DataSync, 1)
+	require.NoError(t, gs.ReSync(ctx, dataSync))
+	require.Equal(t, first, (<-dataSync).Metadata[CommitMetadataKey])
+
+	// resyncs fetch the ref again, and fall back to the last fetched commit
+	gs.Ref = "main"
+	require.NoError(t, gs.ReSync(ctx, dataSync))
+	require.JSONEq(t, `{"flags":{"b":{}}}`, (<-dataSync).FlagData)
+	gs.Ref = "unknown"
+	require.NoError(t, gs.ReSync(ctx, dataSync))
+	require.JSONEq(t, `{"flags":{"b":{}}}`, (<-dataSync).FlagData)
+
+	gs.Ref = "v1"
+	gs.Paths = []string{"missing.json"}
+	_, _, err = gs.Fetch(ctx)
+	require.Error(t, err)
+
+	gs.Ref = "unknown"
+	_, _, err = gs.Fetch(ctx)
+	require.Error(t, err)
+}
+
+func TestInit(t *testing.T) {
+	require.Error(t, NewGitSync("repository", nil, logger.NewLogger(nil, false)).Init(context.Background()))
+}
+
+func TestLocalRepository(t *testing.T) {
+	repo := newRepository(t)
+	repo.commit(t, map[string]string{"flags.json": `{"flags":{"a":{}}}`})
+
+	ctx := context.Background()
+	gs := NewGitSync(repo.dir, []string{"flags.json"}, logger.NewLogger(nil, false))
+	require.NoError(t, gs.Init(ctx))
+	dir := gs.dir
+	require.DirExists(t, dir)
+
+	// initializing again, as on retries, reuses the local repository
+	require.NoError(t, gs.Init(ctx))
+	require.Equal(t, dir, gs.dir)
+
+	// the local repository is removed once Sync returns, on error as well
+	gs.Ref = "unknown"
+	require.Error(t, gs.Sync(ctx, make(chan sync.DataSync, 1)))
+	require.NoDirExists(t, dir)
+	_, _, err := gs.Fetch(ctx)
+	require.Error(t, err)
+
+	gs.Ref = DefaultRef
+	require.NoError(t, gs.Init(ctx))
+	require.DirExists(t, gs.dir)
+	_, _, err = gs.Fetch(ctx)
+	require.NoError(t, err)
+	dir = gs.dir
+	require.NoError(t, gs.Close())
+	require.NoDirExists(t, dir)
+	require.NoError(t, gs.Close())
+}
+
+type repository struct {
+	dir string
+}
+
+func newRepository(t *testing.T) repository {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git executable not found")
+	}
+
+	r := repository{dir: t.TempDir()}
+	r.git(t, "init", "--quiet", "--initial-branch=main")
+	return r
+}
+
+func (r repository) commit(t *testing.T, files map[string]string) string {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(r.dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	r.git(t, "add", "--all")
+	r.git(t, "-c", "user.name=flagd", "-c", "user.email=flagd@example.com", "commit", "--quiet", "-m", "update")
+	return r.git(t, "rev-parse", "HEAD")
+}
+
+func (r repository) git(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", r.dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func receive(t *testing.T, dataSync <-chan sync.DataSync) sync.DataSync {
+	t.Helper()
+	select {
+	case data := <-dataSync:
+		return data
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for datasync")
+	}
+	return sync.DataSync{}
+}
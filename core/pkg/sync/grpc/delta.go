@@ -0,0 +1,178 @@
+package grpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+const (
+	// DeltaMetadataKey is the gRPC metadata key set by flag sync clients able to apply delta payloads
+	DeltaMetadataKey = "flagd-sync-delta"
+	// RevisionHeaderKey is the gRPC header carrying the revision of the flag configuration returned by FetchAllFlags
+	RevisionHeaderKey = "flagd-sync-revision"
+	// RevisionMetadataKey holds the revision of the flag configuration served by the flag sync service, in the
+	// metadata of the flag configurations it sends and of the flag configurations received from it
+	RevisionMetadataKey = "syncRevision"
+)
+
+// Delta is the payload of flag sync responses sent to clients applying deltas. It either carries the full flag
+// configuration, or the flags added, updated and deleted since the previous payload. Each part is a flag configuration
+// of its own, holding the top level properties (such as shared evaluators) of the full configuration. Revision is the
+// revision of the flag configuration the delta brings the subscriber to, if the server tracks revisions.
+type Delta struct {
+	Version  uint64          `json:"version"`
+	Revision uint64          `json:"revision,omitempty"`
+	All      json.RawMessage `json:"all,omitempty"`
+	Add      json.RawMessage `json:"add,omitempty"`
+	Update   json.RawMessage `json:"update,omitempty"`
+	Delete   json.RawMessage `json:"delete,omitempty"`
+}
+
+// deltaEnvelope sets delta payloads apart from plain flag configurations
+type deltaEnvelope struct {
+	Delta *Delta `json:"$delta"`
+}
+
+// RevisionOf returns the revision of the flag configuration sent by the flag sync service, zero if unknown
+func RevisionOf(metadata map[string]interface{}) uint64 {
+	revision, _ := metadata[RevisionMetadataKey].(uint64)
+	return revision
+}
+
+// parseDelta returns the delta carried by the flag sync payload, if any
+func parseDelta(payload string) (*Delta, bool) {
+	var envelope deltaEnvelope
+	if err := json.Unmarshal([]byte(payload), &envelope); err != nil || envelope.Delta == nil {
+		return nil, false
+	}
+
+	return envelope.Delta, true
+}
+
+// DeltaEncoder computes the delta payloads of a single subscriber, based on the flag configuration last sent to it.
+// The full configuration is sent initially, every snapshotInterval payloads and whenever top level properties other
+// than the flags change.
+type DeltaEncoder struct {
+	snapshotInterval uint64
+	version          uint64
+	flags            map[string]interface{}
+	properties       map[string]interface{}
+}
+
+func NewDeltaEncoder(snapshotInterval uint64) *DeltaEncoder {
+	return &DeltaEncoder{
+		snapshotInterval: snapshotInterval,
+	}
+}
+
+// Encode returns the payload bringing the subscriber from the last sent flag configuration to the given one, of the
+// given revision (zero if unknown). On error, the encoder is reset so that the next payload is a full one.
+func (e *DeltaEncoder) Encode(flagConfiguration string, revision uint64) (string, error) {
+	payload, err := e.encode(flagConfiguration, revision)
+	if err != nil {
+		e.flags = nil
+		return "", err
+	}
+
+	return payload, nil
+}
+
+func (e *DeltaEncoder) encode(flagConfiguration string, revision uint64) (string, error) {
+	var properties map[string]interface{}
+	if err := json.Unmarshal([]byte(flagConfiguration), &properties); err != nil {
+		return "", fmt.Errorf("error parsing flag configuration: %w", err)
+	}
+
+	flags, _ := properties["flags"].(map[string]interface{})
+	delete(properties, "flags")
+
+	e.version++
+	delta := Delta{Version: e.version, Revision: revision}
+
+	if e.snapshotRequired(properties) {
+		delta.All = json.RawMessage(flagConfiguration)
+	} else {
+		added, updated, deleted := diff(e.flags, flags)
+
+		var err error
+		if delta.Add, err = deltaPart(properties, added); err != nil {
+			return "", err
+		}
+		if delta.Update, err = deltaPart(properties, updated); err != nil {
+			return "", err
+		}
+		if delta.Delete, err = deltaPart(properties, deleted); err != nil {
+			return "", err
+		}
+	}
+
+	payload, err := json.Marshal(deltaEnvelope{Delta: &delta})
+	if err != nil {
+		return "", fmt.Errorf("error marshalling delta payload: %w", err)
+	}
+
+	e.flags = flags
+	if e.flags == nil {
+		e.flags = map[string]interface{}{}
+	}
+	e.properties = properties
+
+	return string(payload), nil
+}
+
+func (e *DeltaEncoder) snapshotRequired(properties map[string]interface{}) bool {
+	if e.flags == nil {
+		return true
+	}
+	if e.snapshotInterval > 0 && e.version%e.snapshotInterval == 0 {
+		return true
+	}
+
+	return !reflect.DeepEqual(e.properties, properties)
+}
+
+// diff compares the flags by key, deleted flags hold their previous definition
+func diff(previous, current map[string]interface{}) (
+	added map[string]interface{}, updated map[string]interface{}, deleted map[string]interface{},
+) {
+	added = map[string]interface{}{}
+	updated = map[string]interface{}{}
+	deleted = map[string]interface{}{}
+
+	for key, flag := range current {
+		previousFlag, ok := previous[key]
+		switch {
+		case !ok:
+			added[key] = flag
+		case !reflect.DeepEqual(previousFlag, flag):
+			updated[key] = flag
+		}
+	}
+	for key, flag := range previous {
+		if _, ok := current[key]; !ok {
+			deleted[key] = flag
+		}
+	}
+
+	return added, updated, deleted
+}
+
+// deltaPart builds the flag configuration of the given flags, nil is returned if there are none
+func deltaPart(properties map[string]interface{}, flags map[string]interface{}) (json.RawMessage, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+
+	part := make(map[string]interface{}, len(properties)+1)
+	for key, value := range properties {
+		part[key] = value
+	}
+	part["flags"] = flags
+
+	data, err := json.Marshal(part)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling delta: %w", err)
+	}
+	return data, nil
+}
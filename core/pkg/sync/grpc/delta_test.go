@@ -0,0 +1,213 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"buf.build/gen/go/open-feature/flagd/grpc/go/flagd/sync/v1/syncv1grpc"
+	v1 "buf.build/gen/go/open-feature/flagd/protocolbuffers/go/flagd/sync/v1"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func decodeDelta(t *testing.T, payload string) *Delta {
+	t.Helper()
+
+	delta, ok := parseDelta(payload)
+	require.True(t, ok, "expected a delta payload")
+	return delta
+}
+
+func TestDeltaEncoder(t *testing.T) {
+	encoder := NewDeltaEncoder(3)
+
+	// first payload is a full one
+	payload, err := encoder.Encode(`{"flags":{"a":{"state":"ENABLED"},"b":{"state":"ENABLED"}}}`, 7)
+	require.NoError(t, err)
+	delta := decodeDelta(t, payload)
+	require.Equal(t, uint64(1), delta.Version)
+	require.Equal(t, uint64(7), delta.Revision)
+	require.JSONEq(t, `{"flags":{"a":{"state":"ENABLED"},"b":{"state":"ENABLED"}}}`, string(delta.All))
+
+	// changed flags only
+	payload, err = encoder.Encode(`{"flags":{"a":{"state":"DISABLED"},"c":{"state":"ENABLED"}}}`, 8)
+	require.NoError(t, err)
+	delta = decodeDelta(t, payload)
+	require.Equal(t, uint64(2), delta.Version)
+	require.Equal(t, uint64(8), delta.Revision)
+	require.Empty(t, delta.All)
+	require.JSONEq(t, `{"flags":{"c":{"state":"ENABLED"}}}`, string(delta.Add))
+	require.JSONEq(t, `{"flags":{"a":{"state":"DISABLED"}}}`, string(delta.Update))
+	require.JSONEq(t, `{"flags":{"b":{"state":"ENABLED"}}}`, string(delta.Delete))
+
+	// periodic full payload
+	payload, err = encoder.Encode(`{"flags":{"a":{"state":"DISABLED"}}}`, 0)
+	require.NoError(t, err)
+	delta = decodeDelta(t, payload)
+	require.Equal(t, uint64(3), delta.Version)
+	require.JSONEq(t, `{"flags":{"a":{"state":"DISABLED"}}}`, string(delta.All))
+
+	// shared evaluators are part of each delta
+	payload, err = encoder.Encode(`{"flags":{"a":{"state":"ENABLED"}}}`, 0)
+	require.NoError(t, err)
+	delta = decodeDelta(t, payload)
+	require.JSONEq(t, `{"flags":{"a":{"state":"ENABLED"}}}`, string(delta.Update))
+
+	// changed top level properties require a full payload
+	payload, err = encoder.Encode(
+		`{"flags":{"a":{"state":"ENABLED"}},"$evaluators":{"x":{"in":["@a.com",{"var":"email"}]}}}`, 0)
+	require.NoError(t, err)
+	delta = decodeDelta(t, payload)
+	require.NotEmpty(t, delta.All)
+
+	// invalid configurations reset the encoder
+	_, err = encoder.Encode(`invalid`, 0)
+	require.Error(t, err)
+	payload, err = encoder.Encode(`{"flags":{}}`, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, decodeDelta(t, payload).All)
+}
+
+func TestParseDelta(t *testing.T) {
+	_, ok := parseDelta(`{"flags":{}}`)
+	require.False(t, ok, "plain flag configurations are not deltas")
+
+	_, ok = parseDelta(`invalid`)
+	require.False(t, ok)
+
+	delta, ok := parseDelta(`{"$delta":{"version":2,"revision":5,"add":{"flags":{}}}}`)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), delta.Version)
+	require.Equal(t, uint64(5), delta.Revision)
+}
+
+func deltaPayload(t *testing.T, delta Delta) string {
+	t.Helper()
+
+	payload, err := json.Marshal(deltaEnvelope{Delta: &delta})
+	require.NoError(t, err)
+	return string(payload)
+}
+
+func Test_StreamListenerDeltas(t *testing.T) {
+	const target = "localBufCon"
+
+	tests := []struct {
+		name             string
+		input            []serverPayload
+		fetchAll         string
+		fetchAllRevision string
+		output           []sync.DataSync
+		outputLen        int
+	}{
+		{
+			name: "deltas are applied through the store operations",
+			input: []serverPayload{
+				{flags: deltaPayload(t, Delta{Version: 1, All: json.RawMessage(`{"flags":{}}`)})},
+				{flags: deltaPayload(t, Delta{
+					Version: 2,
+					Add:     json.RawMessage(`{"flags":{"a":{}}}`),
+					Update:  json.RawMessage(`{"flags":{"b":{}}}`),
+					Delete:  json.RawMessage(`{"flags":{"c":{}}}`),
+				})},
+			},
+			output: []sync.DataSync{
+				{FlagData: `{"flags":{}}`, Type: sync.ALL},
+				{FlagData: `{"flags":{"a":{}}}`, Type: sync.ADD},
+				{FlagData: `{"flags":{"b":{}}}`, Type: sync.UPDATE},
+				{FlagData: `{"flags":{"c":{}}}`, Type: sync.DELETE},
+			},
+		},
+		{
+			name: "version gaps fall back to the full configuration",
+			input: []serverPayload{
+				{flags: deltaPayload(t, Delta{Version: 1, All: json.RawMessage(`{"flags":{}}`)})},
+				{flags: deltaPayload(t, Delta{Version: 3, Add: json.RawMessage(`{"flags":{"a":{}}}`)})},
+			},
+			fetchAll: `{"flags":{"a":{},"b":{}}}`,
+			output: []sync.DataSync{
+				{FlagData: `{"flags":{}}`, Type: sync.ALL},
+				{FlagData: `{"flags":{"a":{},"b":{}}}`, Type: sync.ALL},
+			},
+		},
+		{
+			name: "revisions are part of the metadata of the source",
+			input: []serverPayload{
+				{flags: deltaPayload(t, Delta{Version: 1, Revision: 4, All: json.RawMessage(`{"flags":{}}`)})},
+				{flags: deltaPayload(t, Delta{Version: 2, Revision: 5, Add: json.RawMessage(`{"flags":{"a":{}}}`)})},
+				{flags: deltaPayload(t, Delta{Version: 4, Revision: 7, Add: json.RawMessage(`{"flags":{"b":{}}}`)})},
+			},
+			fetchAll:         `{"flags":{"a":{},"b":{}}}`,
+			fetchAllRevision: "7",
+			output: []sync.DataSync{
+				{FlagData: `{"flags":{}}`, Type: sync.ALL, Metadata: map[string]interface{}{RevisionMetadataKey: uint64(4)}},
+				{FlagData: `{"flags":{"a":{}}}`, Type: sync.ADD, Metadata: map[string]interface{}{RevisionMetadataKey: uint64(5)}},
+				{
+					FlagData: `{"flags":{"a":{},"b":{}}}`,
+					Type:     sync.ALL,
+					Metadata: map[string]interface{}{RevisionMetadataKey: uint64(7)},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bufCon := bufconn.Listen(5)
+
+			bufServer := bufferedServer{
+				listener:              bufCon,
+				mockResponses:         test.input,
+				fetchAllFlagsResponse: &v1.FetchAllFlagsResponse{FlagConfiguration: test.fetchAll},
+			}
+			if test.fetchAllRevision != "" {
+				bufServer.fetchAllFlagsHeader = metadata.Pairs(RevisionHeaderKey, test.fetchAllRevision)
+			}
+
+			// start server
+			go serve(&bufServer)
+
+			// initialize client
+			dial, err := grpc.Dial(target,
+				grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+					return bufCon.Dial()
+				}),
+				grpc.WithTransportCredentials(insecure.NewCredentials()))
+			require.NoError(t, err)
+
+			grpcSync := Sync{
+				URI:    target,
+				Logger: logger.NewLogger(nil, false),
+				client: syncv1grpc.NewFlagSyncServiceClient(dial),
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			syncChan := make(chan sync.DataSync, len(test.output))
+			go func() {
+				_ = grpcSync.Sync(ctx, syncChan)
+			}()
+
+			for _, expected := range test.output {
+				select {
+				case out := <-syncChan:
+					require.Equal(t, expected.Type, out.Type)
+					require.JSONEq(t, expected.FlagData, out.FlagData)
+					require.Equal(t, target, out.Source)
+					require.Equal(t, expected.Metadata, out.Metadata)
+				case <-time.After(5 * time.Second):
+					t.Fatal("timeout waiting for data sync")
+				}
+			}
+		})
+	}
+}
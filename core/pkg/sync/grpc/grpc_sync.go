@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
 	msync "sync"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/open-feature/flagd/core/pkg/sync"
 	grpccredential "github.com/open-feature/flagd/core/pkg/sync/grpc/credentials"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -38,8 +40,6 @@ type FlagSyncServiceClientResponse interface {
 	syncv1grpc.FlagSyncService_SyncFlagsClient
 }
 
-var once msync.Once
-
 type Sync struct {
 	CertPath          string
 	CredentialBuilder grpccredential.Builder
@@ -51,6 +51,7 @@ type Sync struct {
 
 	client FlagSyncServiceClient
 	ready  bool
+	once   msync.Once
 }
 
 func (g *Sync) Init(ctx context.Context) error {
@@ -76,25 +77,47 @@ func (g *Sync) Init(ctx context.Context) error {
 }
 
 func (g *Sync) ReSync(ctx context.Context, dataSync chan<- sync.DataSync) error {
-	res, err := g.client.FetchAllFlags(ctx, &v1.FetchAllFlagsRequest{})
+	var header metadata.MD
+	res, err := g.client.FetchAllFlags(ctx, &v1.FetchAllFlagsRequest{}, grpc.Header(&header))
 	if err != nil {
 		err = fmt.Errorf("error fetching all flags: %w", err)
 		g.Logger.Error(err.Error())
 		return err
 	}
+
+	var revision uint64
+	if values := header.Get(RevisionHeaderKey); len(values) > 0 {
+		if revision, err = strconv.ParseUint(values[0], 10, 64); err != nil {
+			g.Logger.Warn(fmt.Sprintf("ignoring invalid flag configuration revision '%s'", values[0]))
+		}
+	}
+
 	dataSync <- sync.DataSync{
 		FlagData: res.GetFlagConfiguration(),
 		Source:   g.URI,
 		Type:     sync.ALL,
+		Metadata: revisionMetadata(revision),
 	}
 	return nil
 }
 
+// revisionMetadata returns the metadata of the flag configurations of the given revision, nil if the revision is
+// unknown
+func revisionMetadata(revision uint64) map[string]interface{} {
+	if revision == 0 {
+		return nil
+	}
+	return map[string]interface{}{RevisionMetadataKey: revision}
+}
+
 func (g *Sync) IsReady() bool {
 	return g.ready
 }
 
 func (g *Sync) Sync(ctx context.Context, dataSync chan<- sync.DataSync) error {
+	// announce that deltas are applied, servers not supporting them keep sending full configurations
+	ctx = metadata.AppendToOutgoingContext(ctx, DeltaMetadataKey, "true")
+
 	// Initialize SyncFlags client. This fails if server connection establishment fails (ex:- grpc server offline)
 	syncClient, err := g.client.SyncFlags(ctx, &v1.SyncFlagsRequest{ProviderId: g.ProviderID, Selector: g.Selector})
 	if err != nil {
@@ -168,22 +191,79 @@ func (g *Sync) connectWithRetry(
 
 // handleFlagSync wraps the stream listening and push updates through dataSync channel
 func (g *Sync) handleFlagSync(stream syncv1grpc.FlagSyncService_SyncFlagsClient, dataSync chan<- sync.DataSync) error {
-	once.Do(func() {
+	g.once.Do(func() {
 		g.ready = true
 	})
 
+	// version of the last delta applied, deltas are only sent by servers supporting them
+	var version uint64
+
 	for {
 		data, err := stream.Recv()
 		if err != nil {
 			return fmt.Errorf("error receiving payload from stream: %w", err)
 		}
 
+		delta, ok := parseDelta(data.FlagConfiguration)
+		if !ok {
+			dataSync <- sync.DataSync{
+				FlagData: data.FlagConfiguration,
+				Source:   g.URI,
+				Type:     sync.ALL,
+			}
+
+			g.Logger.Debug("received full configuration payload")
+			continue
+		}
+
+		if err := g.applyDelta(stream.Context(), delta, version, dataSync); err != nil {
+			return err
+		}
+		version = delta.Version
+	}
+}
+
+// applyDelta pushes the parts of the delta through the dataSync channel. If deltas went missing since the last applied
+// version, the full flag configuration is fetched instead.
+func (g *Sync) applyDelta(ctx context.Context, delta *Delta, version uint64, dataSync chan<- sync.DataSync) error {
+	if len(delta.All) > 0 {
 		dataSync <- sync.DataSync{
-			FlagData: data.FlagConfiguration,
+			FlagData: string(delta.All),
 			Source:   g.URI,
 			Type:     sync.ALL,
+			Metadata: revisionMetadata(delta.Revision),
 		}
 
-		g.Logger.Debug("received full configuration payload")
+		g.Logger.Debug(fmt.Sprintf("received full configuration payload, version %d", delta.Version))
+		return nil
+	}
+
+	if delta.Version != version+1 {
+		g.Logger.Warn(fmt.Sprintf("expected delta version %d but received %d, fetching the full configuration",
+			version+1, delta.Version))
+		return g.ReSync(ctx, dataSync)
+	}
+
+	parts := []struct {
+		syncType sync.Type
+		flags    []byte
+	}{
+		{syncType: sync.ADD, flags: delta.Add},
+		{syncType: sync.UPDATE, flags: delta.Update},
+		{syncType: sync.DELETE, flags: delta.Delete},
 	}
+	for _, part := range parts {
+		if len(part.flags) == 0 {
+			continue
+		}
+		dataSync <- sync.DataSync{
+			FlagData: string(part.flags),
+			Source:   g.URI,
+			Type:     part.syncType,
+			Metadata: revisionMetadata(delta.Revision),
+		}
+	}
+
+	g.Logger.Debug(fmt.Sprintf("received delta payload, version %d", delta.Version))
+	return nil
 }
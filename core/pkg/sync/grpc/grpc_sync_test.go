@@ -23,6 +23,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/test/bufconn"
 )
 
@@ -544,6 +545,7 @@ type bufferedServer struct {
 	listener              *bufconn.Listener
 	mockResponses         []serverPayload
 	fetchAllFlagsResponse *v1.FetchAllFlagsResponse
+	fetchAllFlagsHeader   metadata.MD
 	fetchAllFlagsError    error
 }
 
@@ -561,7 +563,14 @@ func (b *bufferedServer) SyncFlags(_ *v1.SyncFlagsRequest, stream syncv1grpc.Fla
 	return nil
 }
 
-func (b *bufferedServer) FetchAllFlags(_ context.Context, _ *v1.FetchAllFlagsRequest) (*v1.FetchAllFlagsResponse, error) {
+func (b *bufferedServer) FetchAllFlags(
+	ctx context.Context, _ *v1.FetchAllFlagsRequest,
+) (*v1.FetchAllFlagsResponse, error) {
+	if b.fetchAllFlagsHeader != nil {
+		if err := grpc.SetHeader(ctx, b.fetchAllFlagsHeader); err != nil {
+			return nil, err
+		}
+	}
 	return b.fetchAllFlagsResponse, b.fetchAllFlagsError
 }
 
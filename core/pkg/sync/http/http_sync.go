@@ -8,12 +8,22 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	msync "sync"
+	"time"
 
 	"github.com/open-feature/flagd/core/pkg/logger"
 	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/open-feature/flagd/core/pkg/sync/file"
 	"golang.org/x/crypto/sha3" //nolint:gosec
 )
 
+const (
+	// maxRetryInterval caps the backoff applied to polls after consecutive failures
+	maxRetryInterval = 5 * time.Minute
+	// unreadyAfterFailures is the number of consecutive failed polls after which the sync is reported as not ready
+	unreadyAfterFailures = 3
+)
+
 type Sync struct {
 	URI         string
 	Client      Client
@@ -24,6 +34,14 @@ type Sync struct {
 	AuthHeader  string
 	Interval    uint32
 	ready       bool
+
+	mx msync.Mutex
+	// lastETag and lastModified are the validators of the last response, sent along with conditional requests
+	lastETag     string
+	lastModified string
+	// failures counts the consecutive failed polls, polls are skipped until nextAttempt
+	failures    int
+	nextAttempt time.Time
 }
 
 // Client defines the behaviour required of a http client
@@ -55,6 +73,8 @@ func (hs *Sync) Init(_ context.Context) error {
 }
 
 func (hs *Sync) IsReady() bool {
+	hs.mx.Lock()
+	defer hs.mx.Unlock()
 	return hs.ready
 }
 
@@ -66,43 +86,13 @@ func (hs *Sync) Sync(ctx context.Context, dataSync chan<- sync.DataSync) error {
 	}
 
 	// Set ready state
+	hs.mx.Lock()
 	hs.ready = true
+	hs.mx.Unlock()
 
 	hs.Logger.Debug(fmt.Sprintf("polling %s every %d seconds", hs.URI, hs.Interval))
 	_ = hs.Cron.AddFunc(fmt.Sprintf("*/%d * * * *", hs.Interval), func() {
-		hs.Logger.Debug(fmt.Sprintf("fetching configuration from %s", hs.URI))
-		body, err := hs.fetchBodyFromURL(ctx, hs.URI)
-		if err != nil {
-			hs.Logger.Error(err.Error())
-			return
-		}
-
-		if len(body) == 0 {
-			hs.Logger.Debug("configuration deleted")
-		} else {
-			if hs.LastBodySHA == "" {
-				hs.Logger.Debug("new configuration created")
-				msg, err := hs.Fetch(ctx)
-				if err != nil {
-					hs.Logger.Error(fmt.Sprintf("error fetching: %s", err.Error()))
-				} else {
-					dataSync <- sync.DataSync{FlagData: msg, Source: hs.URI, Type: sync.ALL}
-				}
-			} else {
-				currentSHA := hs.generateSha(body)
-				if hs.LastBodySHA != currentSHA {
-					hs.Logger.Debug("configuration modified")
-					msg, err := hs.Fetch(ctx)
-					if err != nil {
-						hs.Logger.Error(fmt.Sprintf("error fetching: %s", err.Error()))
-					} else {
-						dataSync <- sync.DataSync{FlagData: msg, Source: hs.URI, Type: sync.ALL}
-					}
-				}
-
-				hs.LastBodySHA = currentSHA
-			}
-		}
+		hs.poll(ctx, dataSync)
 	})
 
 	hs.Cron.Start()
@@ -115,13 +105,56 @@ func (hs *Sync) Sync(ctx context.Context, dataSync chan<- sync.DataSync) error {
 	return nil
 }
 
-func (hs *Sync) fetchBodyFromURL(ctx context.Context, url string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, bytes.NewBuffer(nil))
+// poll fetches the flag configuration once, and sends it if it changed. After a failure, polls are skipped with an
+// exponential backoff, and the sync is reported as not ready once several polls failed in a row.
+func (hs *Sync) poll(ctx context.Context, dataSync chan<- sync.DataSync) {
+	hs.mx.Lock()
+	skip := time.Now().Before(hs.nextAttempt)
+	hs.mx.Unlock()
+	if skip {
+		return
+	}
+
+	hs.Logger.Debug(fmt.Sprintf("fetching configuration from %s", hs.URI))
+	msg, modified, err := hs.fetch(ctx, true)
+
+	hs.mx.Lock()
+	if err != nil {
+		hs.failures++
+		retryInterval := time.Duration(hs.Interval) * time.Second << min(hs.failures-1, 16)
+		retryInterval = min(retryInterval, maxRetryInterval)
+		hs.nextAttempt = time.Now().Add(retryInterval)
+		if hs.failures >= unreadyAfterFailures {
+			hs.ready = false
+		}
+		hs.mx.Unlock()
+		hs.Logger.Error(fmt.Sprintf("error fetching %s, retrying in %s: %s", hs.URI, retryInterval, err.Error()))
+		return
+	}
+	hs.failures = 0
+	hs.nextAttempt = time.Time{}
+	hs.ready = true
+	hs.mx.Unlock()
+
+	if modified {
+		hs.Logger.Debug("configuration modified")
+		dataSync <- sync.DataSync{FlagData: msg, Source: hs.URI, Type: sync.ALL}
+	}
+}
+
+// fetch requests the flag configuration. Conditional requests return false if the configuration did not change since
+// the last response, either because the server responded 304 Not Modified or because the body is the same.
+func (hs *Sync) fetch(ctx context.Context, conditional bool) (string, bool, error) {
+	if hs.URI == "" {
+		return "", false, errors.New("no HTTP URL string set")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", hs.URI, bytes.NewBuffer(nil))
 	if err != nil {
-		return nil, fmt.Errorf("error creating request for url %s: %w", url, err)
+		return "", false, fmt.Errorf("error creating request for url %s: %w", hs.URI, err)
 	}
 
-	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Accept", "application/json, application/yaml;q=0.9")
 
 	if hs.AuthHeader != "" {
 		req.Header.Set("Authorization", hs.AuthHeader)
@@ -130,9 +163,21 @@ func (hs *Sync) fetchBodyFromURL(ctx context.Context, url string) ([]byte, error
 		req.Header.Set("Authorization", bearer)
 	}
 
+	hs.mx.Lock()
+	lastBodySHA := hs.LastBodySHA
+	if conditional {
+		if hs.lastETag != "" {
+			req.Header.Set("If-None-Match", hs.lastETag)
+		}
+		if hs.lastModified != "" {
+			req.Header.Set("If-Modified-Since", hs.lastModified)
+		}
+	}
+	hs.mx.Unlock()
+
 	resp, err := hs.Client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error calling endpoint %s: %w", url, err)
+		return "", false, fmt.Errorf("error calling endpoint %s: %w", hs.URI, err)
 	}
 	defer func() {
 		err = resp.Body.Close()
@@ -141,12 +186,37 @@ func (hs *Sync) fetchBodyFromURL(ctx context.Context, url string) ([]byte, error
 		}
 	}()
 
+	if resp.StatusCode == http.StatusNotModified {
+		return "", false, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", false, fmt.Errorf("error fetching %s: unexpected status %d", hs.URI, resp.StatusCode)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read body to bytes: %w", err)
+		return "", false, fmt.Errorf("unable to read body to bytes: %w", err)
+	}
+	if len(body) == 0 {
+		hs.Logger.Debug("configuration deleted")
+		return "", false, nil
+	}
+
+	msg := string(body)
+	if file.IsYAMLContentType(resp.Header.Get("Content-Type")) {
+		if msg, err = file.YAMLToJSON(body); err != nil {
+			return "", false, fmt.Errorf("error converting YAML response of %s: %w", hs.URI, err)
+		}
 	}
 
-	return body, nil
+	sha := hs.generateSha(body)
+	hs.mx.Lock()
+	hs.LastBodySHA = sha
+	hs.lastETag = resp.Header.Get("ETag")
+	hs.lastModified = resp.Header.Get("Last-Modified")
+	hs.mx.Unlock()
+
+	return msg, sha != lastBodySHA, nil
 }
 
 func (hs *Sync) generateSha(body []byte) string {
@@ -155,18 +225,8 @@ func (hs *Sync) generateSha(body []byte) string {
 	return base64.URLEncoding.EncodeToString(hasher.Sum(nil))
 }
 
+// Fetch requests the flag configuration unconditionally and returns it as JSON, converting YAML responses if needed
 func (hs *Sync) Fetch(ctx context.Context) (string, error) {
-	if hs.URI == "" {
-		return "", errors.New("no HTTP URL string set")
-	}
-
-	body, err := hs.fetchBodyFromURL(ctx, hs.URI)
-	if err != nil {
-		return "", err
-	}
-	if len(body) != 0 {
-		hs.LastBodySHA = hs.generateSha(body)
-	}
-
-	return string(body), nil
+	msg, _, err := hs.fetch(ctx, false)
+	return msg, err
 }
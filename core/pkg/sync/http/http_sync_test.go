@@ -27,7 +27,10 @@ func TestSimpleSync(t *testing.T) {
 	mockCron.EXPECT().Start().Times(1)
 
 	mockClient := syncmock.NewMockClient(ctrl)
-	mockClient.EXPECT().Do(gomock.Any()).Return(&http.Response{Body: io.NopCloser(strings.NewReader(resp))}, nil)
+	mockClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(resp)),
+	}, nil)
 
 	httpSync := Sync{
 		URI:         "http://localhost",
@@ -64,16 +67,17 @@ func TestHTTPSync_Fetch(t *testing.T) {
 		bearerToken    string
 		authHeader     string
 		lastBodySHA    string
-		handleResponse func(*testing.T, Sync, string, error)
+		handleResponse func(*testing.T, *Sync, string, error)
 	}{
 		"success": {
 			setup: func(t *testing.T, client *syncmock.MockClient) {
 				client.EXPECT().Do(gomock.Any()).Return(&http.Response{
-					Body: io.NopCloser(strings.NewReader("test response")),
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("test response")),
 				}, nil)
 			},
 			uri: "http://localhost",
-			handleResponse: func(t *testing.T, _ Sync, fetched string, err error) {
+			handleResponse: func(t *testing.T, _ *Sync, fetched string, err error) {
 				if err != nil {
 					t.Fatalf("fetch: %v", err)
 				}
@@ -85,7 +89,7 @@ func TestHTTPSync_Fetch(t *testing.T) {
 		},
 		"return an error if no uri": {
 			setup: func(t *testing.T, client *syncmock.MockClient) {},
-			handleResponse: func(t *testing.T, _ Sync, fetched string, err error) {
+			handleResponse: func(t *testing.T, _ *Sync, fetched string, err error) {
 				if err == nil {
 					t.Error("expected err, got nil")
 				}
@@ -94,12 +98,13 @@ func TestHTTPSync_Fetch(t *testing.T) {
 		"update last body sha": {
 			setup: func(t *testing.T, client *syncmock.MockClient) {
 				client.EXPECT().Do(gomock.Any()).Return(&http.Response{
-					Body: io.NopCloser(strings.NewReader("test response")),
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("test response")),
 				}, nil)
 			},
 			uri:         "http://localhost",
 			lastBodySHA: "",
-			handleResponse: func(t *testing.T, httpSync Sync, _ string, err error) {
+			handleResponse: func(t *testing.T, httpSync *Sync, _ string, err error) {
 				if err != nil {
 					t.Fatalf("fetch: %v", err)
 				}
@@ -120,13 +125,16 @@ func TestHTTPSync_Fetch(t *testing.T) {
 					if actualAuthHeader != "Bearer "+expectedToken {
 						t.Fatalf("expected Authorization header to be 'Bearer %s', got %s", expectedToken, actualAuthHeader)
 					}
-					return &http.Response{Body: io.NopCloser(strings.NewReader("test response"))}, nil
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader("test response")),
+					}, nil
 				})
 			},
 			uri:         "http://localhost",
 			bearerToken: "bearer-1234",
 			lastBodySHA: "",
-			handleResponse: func(t *testing.T, httpSync Sync, _ string, err error) {
+			handleResponse: func(t *testing.T, httpSync *Sync, _ string, err error) {
 				if err != nil {
 					t.Fatalf("fetch: %v", err)
 				}
@@ -147,13 +155,16 @@ func TestHTTPSync_Fetch(t *testing.T) {
 					if actualAuthHeader != expectedHeader {
 						t.Fatalf("expected Authorization header to be '%s', got %s", expectedHeader, actualAuthHeader)
 					}
-					return &http.Response{Body: io.NopCloser(strings.NewReader("test response"))}, nil
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader("test response")),
+					}, nil
 				})
 			},
 			uri:         "http://localhost",
 			authHeader:  "Basic dXNlcjpwYXNz",
 			lastBodySHA: "",
-			handleResponse: func(t *testing.T, httpSync Sync, _ string, err error) {
+			handleResponse: func(t *testing.T, httpSync *Sync, _ string, err error) {
 				if err != nil {
 					t.Fatalf("fetch: %v", err)
 				}
@@ -166,6 +177,42 @@ func TestHTTPSync_Fetch(t *testing.T) {
 				}
 			},
 		},
+		"yaml response": {
+			setup: func(t *testing.T, client *syncmock.MockClient) {
+				client.EXPECT().Do(gomock.Any()).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/yaml; charset=utf-8"}},
+					Body:       io.NopCloser(strings.NewReader("flags:\n  a:\n    state: ENABLED\n")),
+				}, nil)
+			},
+			uri: "http://localhost",
+			handleResponse: func(t *testing.T, _ *Sync, fetched string, err error) {
+				if err != nil {
+					t.Fatalf("fetch: %v", err)
+				}
+				expected := `{"flags":{"a":{"state":"ENABLED"}}}`
+				if fetched != expected {
+					t.Errorf("expected fetched to be: '%s', got: '%s'", expected, fetched)
+				}
+			},
+		},
+		"return an error on error status": {
+			setup: func(t *testing.T, client *syncmock.MockClient) {
+				client.EXPECT().Do(gomock.Any()).Return(&http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       io.NopCloser(strings.NewReader("internal error")),
+				}, nil)
+			},
+			uri: "http://localhost",
+			handleResponse: func(t *testing.T, httpSync *Sync, _ string, err error) {
+				if err == nil {
+					t.Error("expected err, got nil")
+				}
+				if httpSync.LastBodySHA != "" {
+					t.Errorf("expected last body sha to be unset, got: '%s'", httpSync.LastBodySHA)
+				}
+			},
+		},
 	}
 
 	for name, tt := range tests {
@@ -184,9 +231,87 @@ func TestHTTPSync_Fetch(t *testing.T) {
 			}
 
 			fetched, err := httpSync.Fetch(context.Background())
-			tt.handleResponse(t, httpSync, fetched, err)
+			tt.handleResponse(t, &httpSync, fetched, err)
+		})
+	}
+}
+
+func TestHTTPSync_Poll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := syncmock.NewMockClient(ctrl)
+
+	var requests []*http.Request
+	respond := func(status int, body string) {
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req)
+			return &http.Response{
+				StatusCode: status,
+				Header: http.Header{
+					"Etag":          []string{`"1"`},
+					"Last-Modified": []string{"Wed, 21 Oct 2015 07:28:00 GMT"},
+				},
+				Body: io.NopCloser(strings.NewReader(body)),
+			}, nil
 		})
 	}
+
+	httpSync := Sync{
+		URI:      "http://localhost",
+		Client:   mockClient,
+		Interval: 1,
+		Logger:   logger.NewLogger(nil, false),
+	}
+	dataSync := make(chan sync.DataSync, 1)
+
+	respond(http.StatusOK, "test response")
+	httpSync.poll(context.Background(), dataSync)
+	if data := <-dataSync; data.FlagData != "test response" {
+		t.Errorf("expected content: %s, but received content: %s", "test response", data.FlagData)
+	}
+	if etag := requests[0].Header.Get("If-None-Match"); etag != "" {
+		t.Errorf("expected no If-None-Match header on first request, got: %s", etag)
+	}
+
+	// not modified responses are not synced, and the validators of the last response are sent along
+	respond(http.StatusNotModified, "")
+	httpSync.poll(context.Background(), dataSync)
+	if len(dataSync) != 0 {
+		t.Error("unexpected datasync of unmodified configuration", <-dataSync)
+	}
+	if etag := requests[1].Header.Get("If-None-Match"); etag != `"1"` {
+		t.Errorf("expected If-None-Match header to be '\"1\"', got: %s", etag)
+	}
+	if modified := requests[1].Header.Get("If-Modified-Since"); modified != "Wed, 21 Oct 2015 07:28:00 GMT" {
+		t.Errorf("expected If-Modified-Since header to be set, got: %s", modified)
+	}
+
+	// failed polls back off, and the sync is not ready after consecutive failures
+	for i := 0; i < unreadyAfterFailures; i++ {
+		if !httpSync.IsReady() {
+			t.Fatalf("expected sync to be ready after %d failures", i)
+		}
+		httpSync.nextAttempt = time.Time{}
+		respond(http.StatusServiceUnavailable, "")
+		httpSync.poll(context.Background(), dataSync)
+	}
+	if httpSync.IsReady() {
+		t.Errorf("expected sync not to be ready after %d failures", unreadyAfterFailures)
+	}
+	if retryIn := time.Until(httpSync.nextAttempt); retryIn <= 2*time.Second || retryIn > 4*time.Second {
+		t.Errorf("expected next attempt in 4 seconds, got: %s", retryIn)
+	}
+	httpSync.poll(context.Background(), dataSync)
+	if len(requests) != 2+unreadyAfterFailures {
+		t.Errorf("expected poll to be skipped during backoff, got %d requests", len(requests))
+	}
+
+	// the sync recovers with the next successful poll
+	httpSync.nextAttempt = time.Time{}
+	respond(http.StatusNotModified, "")
+	httpSync.poll(context.Background(), dataSync)
+	if !httpSync.IsReady() {
+		t.Error("expected sync to be ready after a successful poll")
+	}
 }
 
 func TestSync_Init(t *testing.T) {
@@ -220,18 +345,19 @@ func TestHTTPSync_Resync(t *testing.T) {
 		uri               string
 		bearerToken       string
 		lastBodySHA       string
-		handleResponse    func(*testing.T, Sync, string, error)
+		handleResponse    func(*testing.T, *Sync, string, error)
 		wantErr           bool
 		wantNotifications []sync.DataSync
 	}{
 		"success": {
 			setup: func(t *testing.T, client *syncmock.MockClient) {
 				client.EXPECT().Do(gomock.Any()).Return(&http.Response{
-					Body: io.NopCloser(strings.NewReader("test response")),
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("test response")),
 				}, nil)
 			},
 			uri: "http://localhost",
-			handleResponse: func(t *testing.T, _ Sync, fetched string, err error) {
+			handleResponse: func(t *testing.T, _ *Sync, fetched string, err error) {
 				if err != nil {
 					t.Fatalf("fetch: %v", err)
 				}
@@ -251,7 +377,7 @@ func TestHTTPSync_Resync(t *testing.T) {
 		},
 		"error response": {
 			setup: func(t *testing.T, client *syncmock.MockClient) {},
-			handleResponse: func(t *testing.T, _ Sync, fetched string, err error) {
+			handleResponse: func(t *testing.T, _ *Sync, fetched string, err error) {
 				if err == nil {
 					t.Error("expected err, got nil")
 				}
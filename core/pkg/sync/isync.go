@@ -58,6 +58,9 @@ type DataSync struct {
 	FlagData string
 	Source   string
 	Type
+	// Metadata describes the flag configuration of the source, such as the commit it was read from. It is exposed
+	// along with the status of the source and in the evaluation metadata of its flags.
+	Metadata map[string]interface{}
 }
 
 // SourceConfig is configuration option for flagd. This maps to startup parameter sources
@@ -72,4 +75,22 @@ type SourceConfig struct {
 	ProviderID  string `json:"providerID,omitempty"`
 	Selector    string `json:"selector,omitempty"`
 	Interval    uint32 `json:"interval,omitempty"`
+	// Namespace the flags of the source are stored in, the default namespace if empty
+	Namespace string `json:"namespace,omitempty"`
+
+	Recursive bool     `json:"recursive,omitempty"`
+	Include   []string `json:"include,omitempty"`
+	Exclude   []string `json:"exclude,omitempty"`
+
+	Ref   string   `json:"ref,omitempty"`
+	Paths []string `json:"paths,omitempty"`
+
+	Endpoint        string `json:"endpoint,omitempty"`
+	Region          string `json:"region,omitempty"`
+	AccessKeyID     string `json:"accessKeyId,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+
+	Listen string `json:"listen,omitempty"`
+	Secret string `json:"secret,omitempty"`
+	Paired string `json:"paired,omitempty"`
 }
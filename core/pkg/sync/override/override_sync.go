@@ -0,0 +1,316 @@
+package override
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	msync "sync"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	enabledState  = "ENABLED"
+	disabledState = "DISABLED"
+)
+
+var (
+	ErrFlagNotFound    = errors.New("flag not found")
+	ErrInvalidOverride = errors.New("invalid override")
+)
+
+// entry is an override along with the timer of its expiry
+type entry struct {
+	model.Override
+	timer *time.Timer
+}
+
+// key identifies the override of a flag of a namespace
+type key struct {
+	namespace string
+	flagKey   string
+}
+
+// Sync is the flag source of runtime overrides. Each change of the overrides of a namespace is sent as a full flag
+// configuration of the override source of the namespace (see model.OverrideSourceOf), holding the overridden flags of
+// the namespace, so that the store handles overrides like the flags of any other source.
+type Sync struct {
+	Logger *logger.Logger
+	store  *store.Flags
+
+	mx        msync.Mutex
+	overrides map[key]*entry
+	// pending holds the flag configurations built from the overrides by namespace, sent by Sync once updated is
+	// signaled
+	pending map[string]string
+	updated chan struct{}
+}
+
+// NewSync creates the override source of the given store, whose flags are looked up when overriding them. The
+// override source of each namespace must be registered as the source of highest priority of the namespace.
+func NewSync(logger *logger.Logger, store *store.Flags) *Sync {
+	return &Sync{
+		Logger:    logger,
+		store:     store,
+		overrides: map[key]*entry{},
+		pending:   map[string]string{},
+		updated:   make(chan struct{}, 1),
+	}
+}
+
+func (s *Sync) Init(_ context.Context) error {
+	return nil
+}
+
+func (s *Sync) IsReady() bool {
+	return true
+}
+
+// Sync sends the flag configurations of the overrides whenever they change. Only the last flag configuration of a
+// namespace is sent if its overrides change faster than they are consumed, changes made before the sync is started
+// are sent once it starts.
+func (s *Sync) Sync(ctx context.Context, dataSync chan<- sync.DataSync) error {
+	s.mx.Lock()
+	s.emitAll()
+	s.mx.Unlock()
+
+	for {
+		select {
+		case <-s.updated:
+			s.mx.Lock()
+			pending := s.pending
+			s.pending = map[string]string{}
+			s.mx.Unlock()
+
+			namespaces := maps.Keys(pending)
+			sort.Strings(namespaces)
+			for _, namespace := range namespaces {
+				select {
+				case dataSync <- sync.DataSync{
+					FlagData: pending[namespace],
+					Source:   model.OverrideSourceOf(namespace),
+					Type:     sync.ALL,
+				}:
+				case <-ctx.Done():
+				}
+			}
+		case <-ctx.Done():
+			s.mx.Lock()
+			defer s.mx.Unlock()
+			for _, e := range s.overrides {
+				if e.timer != nil {
+					e.timer.Stop()
+				}
+			}
+			return nil
+		}
+	}
+}
+
+func (s *Sync) ReSync(_ context.Context, _ chan<- sync.DataSync) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.emitAll()
+	return nil
+}
+
+// Set overrides the flag of the namespace with the given variant, or disables it. A positive ttl lets the override
+// expire.
+func (s *Sync) Set(
+	namespace string, flagKey string, variant string, disabled bool, ttl time.Duration,
+) (model.Override, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	k := key{namespace: namespace, flagKey: flagKey}
+	base, err := s.base(k)
+	if err != nil {
+		return model.Override{}, err
+	}
+
+	switch {
+	case disabled && variant != "":
+		return model.Override{}, fmt.Errorf("%w: a flag can't be both disabled and forced to a variant", ErrInvalidOverride)
+	case !disabled && variant == "":
+		return model.Override{}, fmt.Errorf("%w: either a variant or disabled is required", ErrInvalidOverride)
+	case !disabled:
+		if _, ok := base.Variants[variant]; !ok {
+			return model.Override{},
+				fmt.Errorf("%w: variant '%s' isn't a variant of flag '%s'", ErrInvalidOverride, variant, flagKey)
+		}
+	}
+
+	e := &entry{
+		Override: model.Override{
+			FlagKey:   flagKey,
+			Namespace: namespace,
+			Variant:   variant,
+			Disabled:  disabled,
+			CreatedAt: time.Now(),
+		},
+	}
+	if ttl > 0 {
+		expiresAt := e.CreatedAt.Add(ttl)
+		e.ExpiresAt = &expiresAt
+		e.timer = time.AfterFunc(ttl, func() {
+			s.expire(k, e)
+		})
+	}
+
+	s.stop(k)
+	s.overrides[k] = e
+	s.Logger.Info(fmt.Sprintf("flag %s overridden: %s", describeFlag(k), describe(e.Override)))
+	s.emit(namespace)
+
+	return e.Override, nil
+}
+
+// Delete removes the override of the flag of the namespace, false is returned if the flag is not overridden
+func (s *Sync) Delete(namespace string, flagKey string) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	k := key{namespace: namespace, flagKey: flagKey}
+	if _, ok := s.overrides[k]; !ok {
+		return false
+	}
+
+	s.stop(k)
+	delete(s.overrides, k)
+	s.Logger.Info(fmt.Sprintf("override of flag %s deleted", describeFlag(k)))
+	s.emit(namespace)
+
+	return true
+}
+
+// List returns the active overrides, sorted by namespace and flag key
+func (s *Sync) List() []model.Override {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	overrides := make([]model.Override, 0, len(s.overrides))
+	for _, e := range s.overrides {
+		overrides = append(overrides, e.Override)
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		if overrides[i].Namespace != overrides[j].Namespace {
+			return overrides[i].Namespace < overrides[j].Namespace
+		}
+		return overrides[i].FlagKey < overrides[j].FlagKey
+	})
+
+	return overrides
+}
+
+// base returns the definition the override of the flag applies to, that is the current definition of the flag from
+// the configured sources, shadowed by the override source while the flag is overridden
+func (s *Sync) base(k key) (model.Flag, error) {
+	flags, ok := s.store.LookupNamespace(k.namespace)
+	if !ok {
+		return model.Flag{}, fmt.Errorf("%w: %s", ErrFlagNotFound, describeFlag(k))
+	}
+	flag, ok := flags.Shadowed(k.flagKey, model.OverrideSourceOf(k.namespace))
+	if !ok {
+		return model.Flag{}, fmt.Errorf("%w: %s", ErrFlagNotFound, describeFlag(k))
+	}
+	return flag, nil
+}
+
+func (s *Sync) expire(k key, e *entry) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	// the override may have been replaced or deleted in the meantime
+	if s.overrides[k] != e {
+		return
+	}
+
+	delete(s.overrides, k)
+	s.Logger.Info(fmt.Sprintf("override of flag %s expired", describeFlag(k)))
+	s.emit(k.namespace)
+}
+
+// stop cancels the expiry of the current override of the flag, it must be called with the lock held
+func (s *Sync) stop(k key) {
+	if e, ok := s.overrides[k]; ok && e.timer != nil {
+		e.timer.Stop()
+	}
+}
+
+// emitAll builds the flag configurations of the overrides of all namespaces, it must be called with the lock held
+func (s *Sync) emitAll() {
+	for _, namespace := range s.store.Namespaces() {
+		s.emit(namespace)
+	}
+}
+
+// emit builds the flag configuration of the overrides of the namespace and signals Sync to send it, it must be called
+// with the lock held. Overrides apply to the current definition of their flag, overrides of flags no longer defined by
+// any source are left out. The flag configuration is sent without holding the lock, so that a busy runtime does not
+// block the overrides.
+func (s *Sync) emit(namespace string) {
+	flags := map[string]model.Flag{}
+	for k, e := range s.overrides {
+		if k.namespace != namespace {
+			continue
+		}
+
+		flag, err := s.base(k)
+		if err != nil {
+			s.Logger.Warn(fmt.Sprintf("override of flag %s not applied: %v", describeFlag(k), err))
+			continue
+		}
+		flag.Source = ""
+		flag.Targeting = nil
+		flag.CompiledTargeting = nil
+		// the schedule would disable the overridden flag, or split its evaluations along a ramp
+		flag.Schedule = nil
+		if e.Disabled {
+			flag.State = disabledState
+		} else {
+			flag.State = enabledState
+			flag.DefaultVariant = e.Variant
+		}
+		flags[k.flagKey] = flag
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{"flags": flags})
+	if err != nil {
+		s.Logger.Error(fmt.Sprintf("error marshalling overrides: %v", err))
+		return
+	}
+
+	s.pending[namespace] = string(payload)
+	select {
+	case s.updated <- struct{}{}:
+	default:
+		// Sync has not sent the previous flag configurations yet, it sends the last ones
+	}
+}
+
+// describeFlag returns the flag key, qualified by its namespace if any
+func describeFlag(k key) string {
+	if k.namespace == "" {
+		return k.flagKey
+	}
+	return fmt.Sprintf("%s (namespace %s)", k.flagKey, k.namespace)
+}
+
+func describe(o model.Override) string {
+	description := fmt.Sprintf("variant %s", o.Variant)
+	if o.Disabled {
+		description = "disabled"
+	}
+	if o.ExpiresAt != nil {
+		description += fmt.Sprintf(" until %s", o.ExpiresAt.Format(time.RFC3339))
+	}
+	return description
+}
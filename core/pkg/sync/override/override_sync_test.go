@@ -0,0 +1,307 @@
+package override
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/model"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSync(t *testing.T) (*Sync, chan sync.DataSync) {
+	t.Helper()
+
+	log := logger.NewLogger(nil, false)
+	flags := store.NewFlags()
+	flags.Merge(log, "A", map[string]model.Flag{
+		"color": {
+			State:          "ENABLED",
+			DefaultVariant: "red",
+			Variants:       map[string]any{"red": "#FF0000", "blue": "#0000FF"},
+			Targeting:      json.RawMessage(`{"if":[true,"blue"]}`),
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := NewSync(log, flags)
+	dataSync := make(chan sync.DataSync, 1)
+	go func() {
+		_ = s.Sync(ctx, dataSync)
+	}()
+
+	// the current overrides are sent once the sync starts
+	requireOverrides(t, dataSync, map[string]model.Flag{})
+	return s, dataSync
+}
+
+func requireOverrides(t *testing.T, dataSync chan sync.DataSync, expected map[string]model.Flag) {
+	t.Helper()
+	requireSourceOverrides(t, dataSync, model.OverrideSource, expected)
+}
+
+func requireSourceOverrides(t *testing.T, dataSync chan sync.DataSync, source string, expected map[string]model.Flag) {
+	t.Helper()
+
+	select {
+	case data := <-dataSync:
+		require.Equal(t, source, data.Source)
+		require.Equal(t, sync.ALL, data.Type)
+
+		var config struct {
+			Flags map[string]model.Flag `json:"flags"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(data.FlagData), &config))
+		require.Equal(t, expected, config.Flags)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for overrides")
+	}
+}
+
+func TestSync_Set(t *testing.T) {
+	s, dataSync := newTestSync(t)
+
+	override, err := s.Set("", "color", "blue", false, 0)
+	require.NoError(t, err)
+	require.Equal(t, "blue", override.Variant)
+	require.Nil(t, override.ExpiresAt)
+	requireOverrides(t, dataSync, map[string]model.Flag{
+		"color": {
+			State:          enabledState,
+			DefaultVariant: "blue",
+			Variants:       map[string]any{"red": "#FF0000", "blue": "#0000FF"},
+		},
+	})
+
+	_, err = s.Set("", "color", "", true, 0)
+	require.NoError(t, err)
+	requireOverrides(t, dataSync, map[string]model.Flag{
+		"color": {
+			State:          disabledState,
+			DefaultVariant: "red",
+			Variants:       map[string]any{"red": "#FF0000", "blue": "#0000FF"},
+		},
+	})
+	require.Len(t, s.List(), 1)
+
+	require.True(t, s.Delete("", "color"))
+	requireOverrides(t, dataSync, map[string]model.Flag{})
+	require.False(t, s.Delete("", "color"))
+	require.Empty(t, s.List())
+}
+
+func TestSync_SetErrors(t *testing.T) {
+	s, _ := newTestSync(t)
+
+	_, err := s.Set("", "missing", "on", false, 0)
+	require.ErrorIs(t, err, ErrFlagNotFound)
+
+	_, err = s.Set("", "color", "green", false, 0)
+	require.ErrorIs(t, err, ErrInvalidOverride)
+
+	_, err = s.Set("", "color", "", false, 0)
+	require.ErrorIs(t, err, ErrInvalidOverride)
+
+	_, err = s.Set("", "color", "blue", true, 0)
+	require.ErrorIs(t, err, ErrInvalidOverride)
+}
+
+func TestSync_Expiry(t *testing.T) {
+	s, dataSync := newTestSync(t)
+
+	override, err := s.Set("", "color", "blue", false, 50*time.Millisecond)
+	require.NoError(t, err)
+	require.NotNil(t, override.ExpiresAt)
+	requireOverrides(t, dataSync, map[string]model.Flag{
+		"color": {
+			State:          enabledState,
+			DefaultVariant: "blue",
+			Variants:       map[string]any{"red": "#FF0000", "blue": "#0000FF"},
+		},
+	})
+
+	requireOverrides(t, dataSync, map[string]model.Flag{})
+	require.Empty(t, s.List())
+}
+
+func TestSync_BusyRuntime(t *testing.T) {
+	s, dataSync := newTestSync(t)
+
+	// the runtime does not consume the overrides while they change, which must not block them
+	_, err := s.Set("", "color", "blue", false, 0)
+	require.NoError(t, err)
+	_, err = s.Set("", "color", "red", false, 0)
+	require.NoError(t, err)
+	require.Len(t, s.List(), 1)
+	require.True(t, s.Delete("", "color"))
+	require.Empty(t, s.List())
+
+	// the last flag configuration is sent once the runtime consumes it
+	require.Eventually(t, func() bool {
+		select {
+		case data := <-dataSync:
+			return data.FlagData == `{"flags":{}}`
+		default:
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSync_Schedule(t *testing.T) {
+	log := logger.NewLogger(nil, false)
+	deactivate := time.Now().Add(-time.Hour)
+	flags := store.NewFlags()
+	flags.Merge(log, "A", map[string]model.Flag{
+		"color": {
+			State:          "ENABLED",
+			DefaultVariant: "red",
+			Variants:       map[string]any{"red": "#FF0000", "blue": "#0000FF"},
+			Schedule: &model.Schedule{
+				Deactivate: &deactivate,
+				Ramp: &model.Ramp{
+					Variant: "blue",
+					Start:   time.Now().Add(-2 * time.Hour),
+					End:     time.Now().Add(time.Hour),
+				},
+			},
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s := NewSync(log, flags)
+	dataSync := make(chan sync.DataSync, 1)
+	go func() {
+		_ = s.Sync(ctx, dataSync)
+	}()
+	requireOverrides(t, dataSync, map[string]model.Flag{})
+
+	// overridden flags are neither deactivated nor ramped up by their schedule
+	_, err := s.Set("", "color", "red", false, 0)
+	require.NoError(t, err)
+	requireOverrides(t, dataSync, map[string]model.Flag{
+		"color": {
+			State:          enabledState,
+			DefaultVariant: "red",
+			Variants:       map[string]any{"red": "#FF0000", "blue": "#0000FF"},
+		},
+	})
+}
+
+func TestSync_Namespace(t *testing.T) {
+	log := logger.NewLogger(nil, false)
+	flags := store.NewFlags()
+	flags.Merge(log, "A", map[string]model.Flag{
+		"color": {State: "ENABLED", DefaultVariant: "red", Variants: map[string]any{"red": "#FF0000"}},
+	})
+	flags.Namespace("team-b").Merge(log, "B", map[string]model.Flag{
+		"color": {State: "ENABLED", DefaultVariant: "green", Variants: map[string]any{"green": "#00FF00"}},
+		"size":  {State: "ENABLED", DefaultVariant: "small", Variants: map[string]any{"small": 1, "large": 2}},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s := NewSync(log, flags)
+	dataSync := make(chan sync.DataSync, 10)
+	go func() {
+		_ = s.Sync(ctx, dataSync)
+	}()
+
+	// the overrides of each namespace are sent by the override source of the namespace
+	requireOverrides(t, dataSync, map[string]model.Flag{})
+	requireSourceOverrides(t, dataSync, "flagd-override/team-b", map[string]model.Flag{})
+
+	_, err := s.Set("team-b", "size", "large", false, 0)
+	require.NoError(t, err)
+	requireSourceOverrides(t, dataSync, "flagd-override/team-b", map[string]model.Flag{
+		"size": {State: enabledState, DefaultVariant: "large", Variants: map[string]any{"small": 1.0, "large": 2.0}},
+	})
+
+	_, err = s.Set("team-b", "color", "red", false, 0)
+	require.ErrorIs(t, err, ErrInvalidOverride, "variants are looked up in the namespace")
+	_, err = s.Set("", "size", "large", false, 0)
+	require.ErrorIs(t, err, ErrFlagNotFound)
+	_, err = s.Set("team-c", "size", "large", false, 0)
+	require.ErrorIs(t, err, ErrFlagNotFound)
+
+	require.Equal(t, []model.Override{{FlagKey: "size", Namespace: "team-b", Variant: "large"}}, withoutTimes(s.List()))
+	require.False(t, s.Delete("", "size"))
+	require.True(t, s.Delete("team-b", "size"))
+	requireSourceOverrides(t, dataSync, "flagd-override/team-b", map[string]model.Flag{})
+}
+
+func TestSync_Store(t *testing.T) {
+	log := logger.NewLogger(nil, false)
+	flags := store.NewFlags()
+	flags.FlagSources = []string{"A", model.OverrideSource}
+	original := model.Flag{
+		State:          "ENABLED",
+		DefaultVariant: "red",
+		Variants:       map[string]any{"red": "#FF0000", "blue": "#0000FF"},
+		Targeting:      json.RawMessage(`{"if":[true,"blue"]}`),
+	}
+	flags.Merge(log, "A", map[string]model.Flag{"color": original})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s := NewSync(log, flags)
+	dataSync := make(chan sync.DataSync, 1)
+	go func() {
+		_ = s.Sync(ctx, dataSync)
+	}()
+
+	// the overrides are merged into the store as the runtime does
+	requireStored := func(expected model.Flag) {
+		t.Helper()
+		data := <-dataSync
+		var config struct {
+			Flags map[string]model.Flag `json:"flags"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(data.FlagData), &config))
+		flags.Merge(log, data.Source, config.Flags)
+		flag, ok := flags.Get("color")
+		require.True(t, ok)
+		require.Equal(t, expected, flag)
+	}
+	original.Source = "A"
+	requireStored(original)
+
+	_, err := s.Set("", "color", "blue", false, 0)
+	require.NoError(t, err)
+	requireStored(model.Flag{
+		State:          enabledState,
+		DefaultVariant: "blue",
+		Variants:       map[string]any{"red": "#FF0000", "blue": "#0000FF"},
+		Source:         model.OverrideSource,
+	})
+
+	// overrides apply to the current definition of the flag rather than to the one overridden first
+	updated := original
+	updated.Variants = map[string]any{"red": "#FF0000", "blue": "#0000FF", "green": "#00FF00"}
+	flags.Merge(log, "A", map[string]model.Flag{"color": updated})
+	_, err = s.Set("", "color", "green", false, 0)
+	require.NoError(t, err)
+	requireStored(model.Flag{
+		State:          enabledState,
+		DefaultVariant: "green",
+		Variants:       map[string]any{"red": "#FF0000", "blue": "#0000FF", "green": "#00FF00"},
+		Source:         model.OverrideSource,
+	})
+
+	// the definition of the source is served again once the override is deleted
+	require.True(t, s.Delete("", "color"))
+	requireStored(updated)
+}
+
+func withoutTimes(overrides []model.Override) []model.Override {
+	for i := range overrides {
+		overrides[i].CreatedAt = time.Time{}
+	}
+	return overrides
+}
@@ -0,0 +1,229 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	msync "sync"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/open-feature/flagd/core/pkg/sync/file"
+)
+
+const (
+	// Prefix of the URIs of S3 objects, s3://bucket/key
+	Prefix = "s3://"
+	// DefaultRegion is the region used if none is configured nor set in the environment
+	DefaultRegion = "us-east-1"
+	// DefaultInterval is the polling interval used if none is configured
+	DefaultInterval = 5 * time.Second
+
+	service = "s3"
+)
+
+// Client defines the behaviour required of a http client
+type Client interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Sync polls an object of an S3-compatible bucket. The object is only downloaded again once its ETag changes.
+// Requests are signed with static credentials, or with the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN environment variables if none are configured. Objects are read anonymously without credentials.
+type Sync struct {
+	// URI of the object, s3://bucket/key
+	URI string
+	// Endpoint is the URL of the S3-compatible service, the AWS endpoint of the region if unset. Objects are addressed
+	// with path-style URLs (endpoint/bucket/key).
+	Endpoint    string
+	Region      string
+	Credentials Credentials
+	// Interval is the polling interval, DefaultInterval if unset
+	Interval time.Duration
+	Client   Client
+	Logger   *logger.Logger
+
+	bucket string
+	key    string
+	mx     msync.RWMutex
+	ready  bool
+	// lastETag is the ETag of the last downloaded object, sent along with conditional requests, and lastFlagData its
+	// content, which is reused as long as the object is not modified
+	lastETag     string
+	lastFlagData string
+}
+
+func (s *Sync) Init(_ context.Context) error {
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(s.URI, Prefix), "/")
+	if !strings.HasPrefix(s.URI, Prefix) || !ok || bucket == "" || key == "" {
+		return fmt.Errorf("invalid S3 URI %s, must be of the form s3://bucket/key", s.URI)
+	}
+	s.bucket = bucket
+	s.key = key
+
+	if s.Region == "" {
+		s.Region = os.Getenv("AWS_REGION")
+	}
+	if s.Region == "" {
+		s.Region = DefaultRegion
+	}
+	if s.Endpoint == "" {
+		s.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", s.Region)
+	}
+	if _, err := url.Parse(s.Endpoint); err != nil {
+		return fmt.Errorf("invalid S3 endpoint %s: %w", s.Endpoint, err)
+	}
+	if s.Credentials.AccessKeyID == "" {
+		s.Credentials = Credentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+	}
+	return nil
+}
+
+func (s *Sync) IsReady() bool {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.ready
+}
+
+func (s *Sync) setReady(val bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.ready = val
+}
+
+func (s *Sync) ReSync(ctx context.Context, dataSync chan<- sync.DataSync) error {
+	msg, err := s.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+	dataSync <- sync.DataSync{FlagData: msg, Source: s.URI, Type: sync.ALL}
+	return nil
+}
+
+func (s *Sync) Sync(ctx context.Context, dataSync chan<- sync.DataSync) error {
+	// Initial fetch
+	msg, err := s.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+	dataSync <- sync.DataSync{FlagData: msg, Source: s.URI, Type: sync.ALL}
+	s.setReady(true)
+
+	interval := s.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	s.Logger.Debug(fmt.Sprintf("polling %s every %s", s.URI, interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			msg, modified, err := s.fetch(ctx)
+			if err != nil {
+				s.Logger.Error(fmt.Sprintf("error fetching: %s", err.Error()))
+				continue
+			}
+			if modified {
+				s.Logger.Debug("configuration modified")
+				dataSync <- sync.DataSync{FlagData: msg, Source: s.URI, Type: sync.ALL}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Fetch returns the content of the object as JSON, converting YAML objects if needed. The object is only downloaded
+// again if it was modified since the last fetch.
+func (s *Sync) Fetch(ctx context.Context) (string, error) {
+	msg, _, err := s.fetch(ctx)
+	return msg, err
+}
+
+// fetch downloads the object unless its ETag matches the one of the last downloaded object, in which case the content
+// of the last downloaded object is returned along with false
+func (s *Sync) fetch(ctx context.Context) (string, bool, error) {
+	if s.bucket == "" {
+		return "", false, errors.New("no S3 object set")
+	}
+
+	segments := strings.Split(s.key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	objectURL := fmt.Sprintf("%s/%s/%s",
+		strings.TrimSuffix(s.Endpoint, "/"), url.PathEscape(s.bucket), strings.Join(segments, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("error creating request for %s: %w", s.URI, err)
+	}
+	s.mx.RLock()
+	etag, lastFlagData := s.lastETag, s.lastFlagData
+	s.mx.RUnlock()
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if s.Credentials.AccessKeyID != "" {
+		req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+		sign(req, s.Credentials, s.Region, service, time.Now())
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", false, fmt.Errorf("error calling endpoint %s: %w", objectURL, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			s.Logger.Debug(fmt.Sprintf("error closing the response body: %s", err.Error()))
+		}
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return lastFlagData, false, nil
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", false, fmt.Errorf("error fetching %s: %s %s", s.URI, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to read body to bytes: %w", err)
+	}
+	msg, err := s.toJSON(body)
+	if err != nil {
+		return "", false, err
+	}
+
+	s.mx.Lock()
+	s.lastETag = resp.Header.Get("ETag")
+	s.lastFlagData = msg
+	s.mx.Unlock()
+	return msg, true, nil
+}
+
+// toJSON converts YAML objects to JSON, other objects are expected to hold JSON
+func (s *Sync) toJSON(body []byte) (string, error) {
+	switch path.Ext(s.key) {
+	case ".yaml", ".yml":
+		msg, err := file.YAMLToJSON(body)
+		if err != nil {
+			return "", fmt.Errorf("error reading %s: %w", s.URI, err)
+		}
+		return msg, nil
+	default:
+		return string(body), nil
+	}
+}
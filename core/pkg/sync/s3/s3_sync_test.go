@@ -0,0 +1,229 @@
+package s3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	msync "sync"
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 serves objects by path, along with their ETag, and records the requests
+type fakeS3 struct {
+	mx       msync.Mutex
+	objects  map[string]string
+	etags    map[string]string
+	requests []*http.Request
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	f.requests = append(f.requests, r)
+	object, ok := f.objects[r.URL.EscapedPath()]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+		return
+	}
+	etag := f.etags[r.URL.EscapedPath()]
+	if etag != "" && r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	_, _ = w.Write([]byte(object))
+}
+
+func TestSync(t *testing.T) {
+	fake := &fakeS3{
+		objects: map[string]string{"/flags/team%20a/flags.json": `{"flags":{"a":{}}}`},
+		etags:   map[string]string{"/flags/team%20a/flags.json": `"1"`},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	s := &Sync{
+		URI:         "s3://flags/team a/flags.json",
+		Endpoint:    server.URL,
+		Region:      "eu-west-1",
+		Credentials: Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "token"},
+		Interval:    20 * time.Millisecond,
+		Client:      server.Client(),
+		Logger:      logger.NewLogger(nil, false),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, s.Init(ctx))
+
+	dataSync := make(chan sync.DataSync, 10)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Sync(ctx, dataSync)
+	}()
+
+	data := receive(t, dataSync)
+	require.Equal(t, sync.DataSync{FlagData: `{"flags":{"a":{}}}`, Source: s.URI, Type: sync.ALL}, data)
+	require.Eventually(t, s.IsReady, time.Second, 10*time.Millisecond)
+
+	// unmodified objects are not downloaded again
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case data := <-dataSync:
+		t.Fatalf("unexpected sync of unmodified object: %v", data)
+	default:
+	}
+
+	fake.mx.Lock()
+	fake.objects["/flags/team%20a/flags.json"] = `{"flags":{"b":{}}}`
+	fake.etags["/flags/team%20a/flags.json"] = `"2"`
+	fake.mx.Unlock()
+	require.Equal(t, `{"flags":{"b":{}}}`, receive(t, dataSync).FlagData)
+
+	cancel()
+	require.NoError(t, <-errs)
+
+	request := fake.requests[0]
+	require.Empty(t, request.Header.Get("If-None-Match"))
+	require.Equal(t, `"1"`, fake.requests[1].Header.Get("If-None-Match"))
+	require.Equal(t, "token", request.Header.Get("X-Amz-Security-Token"))
+	require.Equal(t, emptyPayloadHash, request.Header.Get("X-Amz-Content-Sha256"))
+	authorization := request.Header.Get("Authorization")
+	require.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKID/"), authorization)
+	require.Contains(t, authorization, "/eu-west-1/s3/aws4_request, "+
+		"SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-security-token, Signature=")
+}
+
+func TestFetch(t *testing.T) {
+	fake := &fakeS3{
+		objects: map[string]string{
+			"/bucket/flags.yaml":  "flags:\n  a:\n    state: ENABLED\n",
+			"/bucket/invalid.yml": "flags: [",
+		},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	tests := map[string]struct {
+		uri     string
+		want    string
+		wantErr bool
+	}{
+		"yaml":    {uri: "s3://bucket/flags.yaml", want: `{"flags":{"a":{"state":"ENABLED"}}}`},
+		"invalid": {uri: "s3://bucket/invalid.yml", wantErr: true},
+		"missing": {uri: "s3://bucket/missing.json", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("AWS_ACCESS_KEY_ID", "")
+			s := &Sync{
+				URI:      tt.uri,
+				Endpoint: server.URL,
+				Client:   server.Client(),
+				Logger:   logger.NewLogger(nil, false),
+			}
+			require.NoError(t, s.Init(context.Background()))
+
+			flagData, err := s.Fetch(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, flagData)
+			require.Empty(t, fake.requests[len(fake.requests)-1].Header.Get("Authorization"), "anonymous request")
+		})
+	}
+}
+
+func TestInit(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	s := &Sync{URI: "s3://bucket/path/flags.json"}
+	require.NoError(t, s.Init(context.Background()))
+	require.Equal(t, "bucket", s.bucket)
+	require.Equal(t, "path/flags.json", s.key)
+	require.Equal(t, DefaultRegion, s.Region)
+	require.Equal(t, "https://s3.us-east-1.amazonaws.com", s.Endpoint)
+	require.Equal(t, Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, s.Credentials)
+
+	for _, uri := range []string{"https://bucket/key", "s3://bucket", "s3://bucket/", "s3:///key"} {
+		require.Error(t, (&Sync{URI: uri}).Init(context.Background()), uri)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// get-vanilla of the AWS Signature Version 4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+	now, err := time.Parse(amzDateFormat, "20150830T123600Z")
+	require.NoError(t, err)
+
+	sign(req, Credentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "service", now)
+	require.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, "+
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31", req.Header.Get("Authorization"))
+}
+
+func receive(t *testing.T, dataSync <-chan sync.DataSync) sync.DataSync {
+	t.Helper()
+	select {
+	case data := <-dataSync:
+		return data
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for datasync")
+	}
+	return sync.DataSync{}
+}
+
+func TestReSync(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	fake := &fakeS3{
+		objects: map[string]string{"/bucket/flags.json": `{"flags":{"a":{}}}`},
+		etags:   map[string]string{"/bucket/flags.json": `"1"`},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	s := &Sync{
+		URI:      "s3://bucket/flags.json",
+		Endpoint: server.URL,
+		Client:   server.Client(),
+		Logger:   logger.NewLogger(nil, false),
+	}
+	ctx := context.Background()
+	require.NoError(t, s.Init(ctx))
+	dataSync := make(chan sync.DataSync, 1)
+
+	// the object is downloaded once, then sent again as long as it is not modified
+	for i := 0; i < 2; i++ {
+		require.NoError(t, s.ReSync(ctx, dataSync))
+		require.Equal(t, sync.DataSync{FlagData: `{"flags":{"a":{}}}`, Source: s.URI, Type: sync.ALL}, <-dataSync)
+	}
+	require.Len(t, fake.requests, 2)
+	require.Empty(t, fake.requests[0].Header.Get("If-None-Match"))
+	require.Equal(t, `"1"`, fake.requests[1].Header.Get("If-None-Match"))
+
+	fake.objects["/bucket/flags.json"] = `{"flags":{"b":{}}}`
+	fake.etags["/bucket/flags.json"] = `"2"`
+	require.NoError(t, s.ReSync(ctx, dataSync))
+	require.Equal(t, `{"flags":{"b":{}}}`, (<-dataSync).FlagData)
+
+	// polls are conditional on the object downloaded by the resync
+	msg, modified, err := s.fetch(ctx)
+	require.NoError(t, err)
+	require.False(t, modified)
+	require.Equal(t, `{"flags":{"b":{}}}`, msg)
+	require.Equal(t, `"2"`, fake.requests[len(fake.requests)-1].Header.Get("If-None-Match"))
+}
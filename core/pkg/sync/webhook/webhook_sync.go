@@ -0,0 +1,265 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	msync "sync"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/open-feature/flagd/core/pkg/sync/file"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// SignatureHeader holds the HMAC-SHA256 signature of the payload keyed with the secret, as sent by GitHub:
+	// sha256=<hex digest>
+	SignatureHeader = "X-Hub-Signature-256"
+	// TokenHeader holds the secret itself, as sent by GitLab
+	TokenHeader = "X-Gitlab-Token"
+
+	maxPayloadSize  = 10 << 20
+	shutdownTimeout = 5 * time.Second
+)
+
+// Sync exposes an HTTP endpoint flag configurations are pushed to. POST requests must be authenticated with the
+// secret, either by an HMAC signature of their payload (SignatureHeader) or by the secret itself (TokenHeader).
+// Without paired source, the payload of the requests is the flag configuration of the source, as JSON or as YAML
+// (according to its Content-Type). With a paired source, the requests trigger a resync of the paired source instead,
+// whose flag configuration is synced as usual.
+type Sync struct {
+	// URI identifies the source, the URI of the paired source if any
+	URI string
+	// Listen is the address the endpoint listens on, such as :8016
+	Listen string
+	Secret string
+	// Paired is the source resynced by the requests to the endpoint, if any
+	Paired sync.ISync
+	Logger *logger.Logger
+
+	mx msync.RWMutex
+	// flagData is the last flag configuration pushed to the endpoint
+	flagData string
+	ready    bool
+}
+
+func (s *Sync) Init(ctx context.Context) error {
+	if s.Listen == "" {
+		return fmt.Errorf("no listen address set for webhook %s", s.URI)
+	}
+	if s.Secret == "" {
+		return fmt.Errorf("no secret set for webhook %s", s.URI)
+	}
+	if s.Paired != nil {
+		if err := s.Paired.Init(ctx); err != nil {
+			return fmt.Errorf("error initializing paired source of webhook %s: %w", s.URI, err)
+		}
+	}
+	return nil
+}
+
+// Close releases the resources of the paired source, if it holds some beyond its Sync call
+func (s *Sync) Close() error {
+	if closer, ok := s.Paired.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("error closing paired source of webhook %s: %w", s.URI, err)
+		}
+	}
+	return nil
+}
+
+func (s *Sync) IsReady() bool {
+	if s.Paired != nil {
+		return s.Paired.IsReady()
+	}
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.ready
+}
+
+func (s *Sync) setReady(val bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.ready = val
+}
+
+// ReSync resyncs the paired source, or sends the last flag configuration pushed to the endpoint again
+func (s *Sync) ReSync(ctx context.Context, dataSync chan<- sync.DataSync) error {
+	if s.Paired != nil {
+		if err := s.Paired.ReSync(ctx, dataSync); err != nil {
+			return fmt.Errorf("error resyncing paired source of webhook %s: %w", s.URI, err)
+		}
+		return nil
+	}
+
+	s.mx.RLock()
+	flagData := s.flagData
+	s.mx.RUnlock()
+	if flagData != "" {
+		dataSync <- sync.DataSync{FlagData: flagData, Source: s.URI, Type: sync.ALL}
+	}
+	return nil
+}
+
+// Sync serves the endpoint along with the paired source, if any, until the context is done
+func (s *Sync) Sync(ctx context.Context, dataSync chan<- sync.DataSync) error {
+	listener, err := net.Listen("tcp", s.Listen)
+	if err != nil {
+		// the paired source is not synced, and would not release its resources otherwise
+		if cErr := s.Close(); cErr != nil {
+			s.Logger.Warn(cErr.Error())
+		}
+		return fmt.Errorf("error listening on %s: %w", s.Listen, err)
+	}
+	s.Logger.Info(fmt.Sprintf("webhook listening on %s", listener.Addr()))
+
+	g, gCtx := errgroup.WithContext(ctx)
+	// resyncs are triggered by a buffered channel, so that concurrent requests result in a single pending resync
+	trigger := make(chan struct{}, 1)
+	server := &http.Server{
+		Handler:           s.handler(gCtx, dataSync, trigger),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	g.Go(func() error {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("error serving webhook: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		<-gCtx.Done()
+		s.setReady(false)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil { //nolint:contextcheck
+			// pending requests are dropped, stopping flagd must not fail because of them
+			s.Logger.Warn(fmt.Sprintf("webhook %s did not shut down gracefully: %s", s.URI, err.Error()))
+			if err := server.Close(); err != nil {
+				s.Logger.Debug(fmt.Sprintf("error closing webhook %s: %s", s.URI, err.Error()))
+			}
+		}
+		return nil
+	})
+
+	if s.Paired == nil {
+		s.setReady(true)
+	} else {
+		g.Go(func() error {
+			return s.Paired.Sync(gCtx, dataSync)
+		})
+		g.Go(func() error {
+			s.resyncOnTrigger(gCtx, trigger, dataSync)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("webhook %s: %w", s.URI, err)
+	}
+	return nil
+}
+
+func (s *Sync) resyncOnTrigger(ctx context.Context, trigger <-chan struct{}, dataSync chan<- sync.DataSync) {
+	for {
+		select {
+		case <-trigger:
+			s.Logger.Debug(fmt.Sprintf("resyncing %s", s.URI))
+			if err := s.Paired.ReSync(ctx, dataSync); err != nil {
+				s.Logger.Error(fmt.Sprintf("error resyncing %s: %s", s.URI, err.Error()))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Sync) handler(ctx context.Context, dataSync chan<- sync.DataSync, trigger chan<- struct{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+		if err != nil {
+			http.Error(w, "unable to read payload", http.StatusBadRequest)
+			return
+		}
+		if !s.verify(r.Header, payload) {
+			s.Logger.Warn(fmt.Sprintf("rejected webhook request from %s: invalid signature", r.RemoteAddr))
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		if s.Paired != nil {
+			select {
+			case trigger <- struct{}{}:
+			default:
+				// a resync is already pending
+			}
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		flagData, err := toJSON(r.Header.Get("Content-Type"), payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mx.Lock()
+		s.flagData = flagData
+		s.mx.Unlock()
+
+		select {
+		case dataSync <- sync.DataSync{FlagData: flagData, Source: s.URI, Type: sync.ALL}:
+			w.WriteHeader(http.StatusAccepted)
+		case <-r.Context().Done():
+		case <-ctx.Done():
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		}
+	}
+}
+
+// verify checks the HMAC signature of the payload, or the secret token if the request is not signed
+func (s *Sync) verify(header http.Header, payload []byte) bool {
+	if signature, ok := strings.CutPrefix(header.Get(SignatureHeader), "sha256="); ok {
+		expected, err := hex.DecodeString(signature)
+		if err != nil {
+			return false
+		}
+		mac := hmac.New(sha256.New, []byte(s.Secret))
+		mac.Write(payload)
+		return hmac.Equal(mac.Sum(nil), expected)
+	}
+	if token := header.Get(TokenHeader); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(s.Secret)) == 1
+	}
+	return false
+}
+
+// toJSON converts YAML payloads to JSON, other payloads must hold JSON
+func toJSON(contentType string, payload []byte) (string, error) {
+	if file.IsYAMLContentType(contentType) {
+		flagData, err := file.YAMLToJSON(payload)
+		if err != nil {
+			return "", fmt.Errorf("invalid YAML payload: %w", err)
+		}
+		return flagData, nil
+	}
+	if !json.Valid(payload) {
+		return "", errors.New("invalid JSON payload")
+	}
+	return string(payload), nil
+}
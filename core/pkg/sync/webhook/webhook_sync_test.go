@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+	msync "sync"
+	"testing"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "s3cr3t"
+
+func TestSync(t *testing.T) {
+	s := &Sync{URI: "pipeline", Listen: freeAddress(t), Secret: secret, Logger: logger.NewLogger(nil, false)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, s.Init(ctx))
+
+	dataSync := make(chan sync.DataSync, 10)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Sync(ctx, dataSync)
+	}()
+	require.Eventually(t, s.IsReady, time.Second, 10*time.Millisecond)
+	url := "http://" + s.Listen + "/hooks/flags"
+
+	tests := map[string]struct {
+		method      string
+		contentType string
+		payload     string
+		header      http.Header
+		status      int
+		want        string
+	}{
+		"signed json": {
+			payload: `{"flags":{"a":{}}}`,
+			header:  http.Header{SignatureHeader: []string{signature(`{"flags":{"a":{}}}`)}},
+			status:  http.StatusAccepted,
+			want:    `{"flags":{"a":{}}}`,
+		},
+		"yaml with token": {
+			contentType: "application/yaml",
+			payload:     "flags:\n  b:\n    state: ENABLED\n",
+			header:      http.Header{TokenHeader: []string{secret}},
+			status:      http.StatusAccepted,
+			want:        `{"flags":{"b":{"state":"ENABLED"}}}`,
+		},
+		"unsigned": {
+			payload: `{"flags":{}}`,
+			status:  http.StatusUnauthorized,
+		},
+		"invalid signature": {
+			payload: `{"flags":{}}`,
+			header:  http.Header{SignatureHeader: []string{signature(`{"flags":{"a":{}}}`)}},
+			status:  http.StatusUnauthorized,
+		},
+		"invalid token": {
+			payload: `{"flags":{}}`,
+			header:  http.Header{TokenHeader: []string{"secret"}},
+			status:  http.StatusUnauthorized,
+		},
+		"invalid json": {
+			payload: `{"flags":`,
+			header:  http.Header{SignatureHeader: []string{signature(`{"flags":`)}},
+			status:  http.StatusBadRequest,
+		},
+		"get": {
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(tt.payload))
+			require.NoError(t, err)
+			for key, values := range tt.header {
+				req.Header[key] = values
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, tt.status, resp.StatusCode)
+
+			if tt.want == "" {
+				require.Empty(t, dataSync)
+				return
+			}
+			data := <-dataSync
+			require.Equal(t, sync.DataSync{FlagData: tt.want, Source: "pipeline", Type: sync.ALL}, data)
+
+			// resyncs send the last pushed flag configuration
+			require.NoError(t, s.ReSync(ctx, dataSync))
+			require.Equal(t, data, <-dataSync)
+		})
+	}
+
+	// connections dialed by the client but never used would otherwise delay the shutdown of the server
+	http.DefaultClient.CloseIdleConnections()
+	cancel()
+	require.NoError(t, <-errs)
+	require.False(t, s.IsReady())
+}
+
+func TestSync_Paired(t *testing.T) {
+	paired := &fakeSync{resyncs: make(chan struct{}, 10)}
+	s := &Sync{
+		URI:    "https://flags.example.com/flags.json",
+		Listen: freeAddress(t),
+		Secret: secret,
+		Paired: paired,
+		Logger: logger.NewLogger(nil, false),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, s.Init(ctx))
+	require.True(t, paired.initialized)
+
+	dataSync := make(chan sync.DataSync, 10)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Sync(ctx, dataSync)
+	}()
+	require.Equal(t, "sync", (<-dataSync).FlagData)
+	require.True(t, s.IsReady())
+
+	require.Eventually(t, func() bool {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+s.Listen, strings.NewReader("{}"))
+		require.NoError(t, err)
+		req.Header.Set(SignatureHeader, signature("{}"))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return false
+		}
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode == http.StatusAccepted
+	}, time.Second, 10*time.Millisecond)
+
+	select {
+	case <-paired.resyncs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for resync of the paired source")
+	}
+	require.Equal(t, "resync", (<-dataSync).FlagData)
+
+	// connections dialed by the client but never used would otherwise delay the shutdown of the server
+	http.DefaultClient.CloseIdleConnections()
+	cancel()
+	require.NoError(t, <-errs)
+}
+
+func TestInit(t *testing.T) {
+	require.Error(t, (&Sync{URI: "pipeline", Secret: secret}).Init(context.Background()))
+	require.Error(t, (&Sync{URI: "pipeline", Listen: ":8016"}).Init(context.Background()))
+}
+
+// fakeSync sends a flag configuration on sync and on each resync
+type fakeSync struct {
+	mx          msync.Mutex
+	initialized bool
+	ready       bool
+	resyncs     chan struct{}
+}
+
+func (f *fakeSync) Init(_ context.Context) error {
+	f.initialized = true
+	return nil
+}
+
+func (f *fakeSync) Sync(ctx context.Context, dataSync chan<- sync.DataSync) error {
+	f.mx.Lock()
+	f.ready = true
+	f.mx.Unlock()
+	dataSync <- sync.DataSync{FlagData: "sync"}
+	<-ctx.Done()
+	return nil
+}
+
+func (f *fakeSync) ReSync(_ context.Context, dataSync chan<- sync.DataSync) error {
+	dataSync <- sync.DataSync{FlagData: "resync"}
+	f.resyncs <- struct{}{}
+	return nil
+}
+
+func (f *fakeSync) IsReady() bool {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	return f.ready
+}
+
+func signature(payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// freeAddress returns a local address with a free port
+func freeAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().String()
+}
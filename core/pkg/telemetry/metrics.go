@@ -2,6 +2,7 @@ package telemetry
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -16,14 +17,21 @@ import (
 const (
 	ProviderName = "flagd"
 
-	FeatureFlagReasonKey = attribute.Key("feature_flag.reason")
-	ExceptionTypeKey     = attribute.Key("ExceptionTypeKeyName")
+	FeatureFlagReasonKey    = attribute.Key("feature_flag.reason")
+	FeatureFlagSourceKey    = attribute.Key("feature_flag.source")
+	FeatureFlagNamespaceKey = attribute.Key("feature_flag.namespace")
+	OverflowPolicyKey       = attribute.Key("feature_flag.event.overflow_policy")
+	EventSubscriberKey      = attribute.Key("feature_flag.event.subscriber")
+	ExceptionTypeKey        = attribute.Key("ExceptionTypeKeyName")
 
 	httpRequestDurationMetric = "http.server.duration"
 	httpResponseSizeMetric    = "http.server.response.size"
 	httpActiveRequestsMetric  = "http.server.active_requests"
 	impressionMetric          = "feature_flag." + ProviderName + ".impression"
 	reasonMetric              = "feature_flag." + ProviderName + ".evaluation.reason"
+	syncRejectionMetric       = "feature_flag." + ProviderName + ".sync.rejection"
+	eventDroppedMetric        = "feature_flag." + ProviderName + ".event.dropped"
+	eventQueueDepthMetric     = "feature_flag." + ProviderName + ".event.queue.depth"
 )
 
 type MetricsRecorder struct {
@@ -32,6 +40,10 @@ type MetricsRecorder struct {
 	httpRequestsInflight      metric.Int64UpDownCounter
 	impressions               metric.Int64Counter
 	reasons                   metric.Int64Counter
+	syncRejections            metric.Int64Counter
+	eventsDropped             metric.Int64Counter
+	eventQueueDepth           metric.Int64ObservableGauge
+	meter                     metric.Meter
 }
 
 func (r MetricsRecorder) HTTPAttributes(svcName, url, method, code string) []attribute.KeyValue {
@@ -59,24 +71,31 @@ func (r MetricsRecorder) InFlightRequestEnd(ctx context.Context, attrs []attribu
 	r.httpRequestsInflight.Add(ctx, -1, metric.WithAttributes(attrs...))
 }
 
-func (r MetricsRecorder) RecordEvaluation(ctx context.Context, err error, reason, variant, key string) {
+// RecordEvaluation records the impression and the reason of an evaluation, the namespace being recorded unless it is
+// the default namespace
+func (r MetricsRecorder) RecordEvaluation(ctx context.Context, err error, namespace, reason, variant, key string) {
 	if err == nil {
-		r.Impressions(ctx, reason, variant, key)
+		r.Impressions(ctx, namespace, reason, variant, key)
 	}
-	r.Reasons(ctx, key, reason, err)
+	r.Reasons(ctx, namespace, key, reason, err)
 }
 
-func (r MetricsRecorder) Impressions(ctx context.Context, reason, variant, key string) {
-	r.impressions.Add(ctx,
-		1,
-		metric.WithAttributes(append(SemConvFeatureFlagAttributes(key, variant), FeatureFlagReason(reason))...))
+func (r MetricsRecorder) Impressions(ctx context.Context, namespace, reason, variant, key string) {
+	attrs := append(SemConvFeatureFlagAttributes(key, variant), FeatureFlagReason(reason))
+	if namespace != "" {
+		attrs = append(attrs, FeatureFlagNamespace(namespace))
+	}
+	r.impressions.Add(ctx, 1, metric.WithAttributes(attrs...))
 }
 
-func (r MetricsRecorder) Reasons(ctx context.Context, key string, reason string, err error) {
+func (r MetricsRecorder) Reasons(ctx context.Context, namespace, key string, reason string, err error) {
 	attrs := []attribute.KeyValue{
 		semconv.FeatureFlagProviderName(ProviderName),
 		FeatureFlagReason(reason),
 	}
+	if namespace != "" {
+		attrs = append(attrs, FeatureFlagNamespace(namespace))
+	}
 	if err == nil {
 		// record flag key only if evaluation is successful
 		attrs = append(attrs, semconv.FeatureFlagKey(key))
@@ -87,6 +106,30 @@ func (r MetricsRecorder) Reasons(ctx context.Context, key string, reason string,
 	r.reasons.Add(ctx, 1, metric.WithAttributes(attrs...))
 }
 
+func (r MetricsRecorder) SyncRejection(ctx context.Context, source string) {
+	r.syncRejections.Add(ctx, 1, metric.WithAttributes(FeatureFlagSource(source)))
+}
+
+func (r MetricsRecorder) EventsDropped(ctx context.Context, policy string, count int64) {
+	r.eventsDropped.Add(ctx, count, metric.WithAttributes(OverflowPolicy(policy)))
+}
+
+// ObserveEventQueueDepth registers a callback reporting the number of queued notifications of each event subscriber,
+// as returned by depths by subscriber id. Subscribers come and go with client connections, only the current ones are
+// observed.
+func (r MetricsRecorder) ObserveEventQueueDepth(depths func() map[string]int) error {
+	_, err := r.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for subscriber, depth := range depths() {
+			o.ObserveInt64(r.eventQueueDepth, int64(depth), metric.WithAttributes(EventSubscriber(subscriber)))
+		}
+		return nil
+	}, r.eventQueueDepth)
+	if err != nil {
+		return fmt.Errorf("unable to register event queue depth callback: %w", err)
+	}
+	return nil
+}
+
 func getDurationView(svcName, viewName string, bucket []float64) msdk.View {
 	return msdk.NewView(
 		msdk.Instrument{
@@ -106,6 +149,22 @@ func FeatureFlagReason(val string) attribute.KeyValue {
 	return FeatureFlagReasonKey.String(val)
 }
 
+func FeatureFlagSource(val string) attribute.KeyValue {
+	return FeatureFlagSourceKey.String(val)
+}
+
+func FeatureFlagNamespace(val string) attribute.KeyValue {
+	return FeatureFlagNamespaceKey.String(val)
+}
+
+func OverflowPolicy(val string) attribute.KeyValue {
+	return OverflowPolicyKey.String(val)
+}
+
+func EventSubscriber(val string) attribute.KeyValue {
+	return EventSubscriberKey.String(val)
+}
+
 func ExceptionType(val string) attribute.KeyValue {
 	return ExceptionTypeKey.String(val)
 }
@@ -153,11 +212,30 @@ func NewOTelRecorder(exporter msdk.Reader, resource *resource.Resource, serviceN
 		metric.WithDescription("Measures the number of evaluations for a given reason."),
 		metric.WithUnit("{reason}"),
 	)
+	syncRejections, _ := meter.Int64Counter(
+		syncRejectionMetric,
+		metric.WithDescription("Measures the number of flag configurations rejected for a given source."),
+		metric.WithUnit("{rejection}"),
+	)
+	eventsDropped, _ := meter.Int64Counter(
+		eventDroppedMetric,
+		metric.WithDescription("Measures the number of notifications dropped for subscribers not keeping up."),
+		metric.WithUnit("{event}"),
+	)
+	eventQueueDepth, _ := meter.Int64ObservableGauge(
+		eventQueueDepthMetric,
+		metric.WithDescription("Measures the number of notifications queued for each subscriber."),
+		metric.WithUnit("{event}"),
+	)
 	return &MetricsRecorder{
 		httpRequestDurHistogram:   hduration,
 		httpResponseSizeHistogram: hsize,
 		httpRequestsInflight:      reqCounter,
 		impressions:               impressions,
 		reasons:                   reasons,
+		syncRejections:            syncRejections,
+		eventsDropped:             eventsDropped,
+		eventQueueDepth:           eventQueueDepth,
+		meter:                     meter,
 	}
 }
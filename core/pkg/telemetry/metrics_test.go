@@ -145,7 +145,7 @@ func TestMetrics(t *testing.T) {
 				rs := resource.NewWithAttributes("testSchema")
 				rec := NewOTelRecorder(exp, rs, svcName)
 				for i := 0; i < n; i++ {
-					rec.Impressions(context.TODO(), "reason", "variant", "key")
+					rec.Impressions(context.TODO(), "", "reason", "variant", "key")
 				}
 			},
 			metricsLen: 1,
@@ -156,10 +156,10 @@ func TestMetrics(t *testing.T) {
 				rs := resource.NewWithAttributes("testSchema")
 				rec := NewOTelRecorder(exp, rs, svcName)
 				for i := 0; i < n; i++ {
-					rec.Reasons(context.TODO(), "keyA", "reason", nil)
+					rec.Reasons(context.TODO(), "", "keyA", "reason", nil)
 				}
 				for i := 0; i < n; i++ {
-					rec.Reasons(context.TODO(), "keyB", "error", fmt.Errorf("err not found"))
+					rec.Reasons(context.TODO(), "", "keyB", "error", fmt.Errorf("err not found"))
 				}
 			},
 			metricsLen: 1,
@@ -170,17 +170,51 @@ func TestMetrics(t *testing.T) {
 				rs := resource.NewWithAttributes("testSchema")
 				rec := NewOTelRecorder(exp, rs, svcName)
 				for i := 0; i < n; i++ {
-					rec.RecordEvaluation(context.TODO(), nil, "reason", "variant", "key")
+					rec.RecordEvaluation(context.TODO(), nil, "", "reason", "variant", "key")
 				}
 				for i := 0; i < n; i++ {
-					rec.RecordEvaluation(context.TODO(), fmt.Errorf("general"), "error", "variant", "key")
+					rec.RecordEvaluation(context.TODO(), fmt.Errorf("general"), "", "error", "variant", "key")
 				}
 				for i := 0; i < n; i++ {
-					rec.RecordEvaluation(context.TODO(), fmt.Errorf("not found"), "error", "variant", "key")
+					rec.RecordEvaluation(context.TODO(), fmt.Errorf("not found"), "", "error", "variant", "key")
 				}
 			},
 			metricsLen: 2,
 		},
+		{
+			name: "SyncRejection",
+			metricFunc: func(exp metric.Reader) {
+				rs := resource.NewWithAttributes("testSchema")
+				rec := NewOTelRecorder(exp, rs, svcName)
+				for i := 0; i < n; i++ {
+					rec.SyncRejection(context.TODO(), "file:flags.json")
+				}
+			},
+			metricsLen: 1,
+		},
+		{
+			name: "EventsDropped",
+			metricFunc: func(exp metric.Reader) {
+				rs := resource.NewWithAttributes("testSchema")
+				rec := NewOTelRecorder(exp, rs, svcName)
+				for i := 0; i < n; i++ {
+					rec.EventsDropped(context.TODO(), "drop-oldest", 1)
+				}
+			},
+			metricsLen: 1,
+		},
+		{
+			name: "EventQueueDepth",
+			metricFunc: func(exp metric.Reader) {
+				rs := resource.NewWithAttributes("testSchema")
+				rec := NewOTelRecorder(exp, rs, svcName)
+				err := rec.ObserveEventQueueDepth(func() map[string]int {
+					return map[string]int{"1": 1, "2": 0}
+				})
+				require.NoError(t, err)
+			},
+			metricsLen: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/runtime"
+	syncbuilder "github.com/open-feature/flagd/core/pkg/sync/builder"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	contextFlagName = "context"
+	flagFlagName    = "flag"
+	outputTable     = "table"
+	timeoutFlagName = "timeout"
+)
+
+// evaluation is the printable result of a flag evaluation
+type evaluation struct {
+	FlagKey  string                 `json:"flagKey"`
+	Value    interface{}            `json:"value"`
+	Variant  string                 `json:"variant,omitempty"`
+	Reason   string                 `json:"reason"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// evalCmd represents the eval command
+var evalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Evaluate flags against an evaluation context",
+	Long: "Evaluate flags offline against an evaluation context. The sources are loaded once and evaluated the same " +
+		"way the flagd service does, without starting it. If no flag is given, all enabled flags are evaluated.",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		uris, _ := flags.GetStringSlice(uriFlagName)
+		flagKey, _ := flags.GetString(flagFlagName)
+		rawContext, _ := flags.GetString(contextFlagName)
+		output, _ := flags.GetString(outputFlagName)
+		timeout, _ := flags.GetDuration(timeoutFlagName)
+
+		if output != outputTable && output != outputJSON {
+			return fmt.Errorf("unsupported output format '%s', use %s or %s", output, outputTable, outputJSON)
+		}
+
+		evalContext := map[string]any{}
+		if rawContext != "" {
+			if err := json.Unmarshal([]byte(rawContext), &evalContext); err != nil {
+				return fmt.Errorf("error parsing evaluation context: %w", err)
+			}
+		}
+
+		sources, err := syncbuilder.ParseSyncProviderURIs(uris)
+		if err != nil {
+			return fmt.Errorf("error parsing sources: %w", err)
+		}
+
+		// diagnostics of the sync providers and the evaluator are only of interest with verbose logging
+		l := zap.NewNop()
+		if Debug {
+			l, err = logger.NewZapLogger(zap.DebugLevel, "console")
+			if err != nil {
+				return fmt.Errorf("can't initialize zap logger: %w", err)
+			}
+		}
+		log := logger.NewLogger(l, Debug)
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		je := runtime.NewEvaluator(log, sources)
+		if err := runtime.LoadSources(ctx, log, je, sources); err != nil {
+			return fmt.Errorf("error loading flags: %w", err)
+		}
+
+		var values []evaluator.AnyValue
+		if flagKey != "" {
+			values = []evaluator.AnyValue{je.ResolveAsAnyValue(ctx, "", flagKey, evalContext)}
+		} else {
+			values = je.ResolveAllValues(ctx, "", evalContext)
+		}
+
+		return printEvaluations(cmd.OutOrStdout(), output, toEvaluations(values))
+	},
+}
+
+func init() {
+	flags := evalCmd.Flags()
+	flags.StringSliceP(uriFlagName, "f", []string{}, "Set a sync provider uri to read flags from, this can be a "+
+		"filepath, URL (HTTP and gRPC) or FeatureFlag custom resource")
+	flags.String(flagFlagName, "", "Key of the flag to evaluate, all flags are evaluated if unset")
+	flags.StringP(contextFlagName, "c", "", "JSON representation of the evaluation context")
+	flags.StringP(outputFlagName, "o", outputTable, "Output format, either table or json")
+	flags.Duration(timeoutFlagName, 10*time.Second, "Maximum time to wait for the sources to provide their flags")
+	_ = evalCmd.MarkFlagRequired(uriFlagName)
+}
+
+func toEvaluations(values []evaluator.AnyValue) []evaluation {
+	evaluations := make([]evaluation, 0, len(values))
+	for _, v := range values {
+		e := evaluation{
+			FlagKey:  v.FlagKey,
+			Value:    v.Value,
+			Variant:  v.Variant,
+			Reason:   v.Reason,
+			Metadata: v.Metadata,
+		}
+		if v.Error != nil {
+			e.Error = v.Error.Error()
+		}
+		evaluations = append(evaluations, e)
+	}
+
+	sort.Slice(evaluations, func(i, j int) bool {
+		return evaluations[i].FlagKey < evaluations[j].FlagKey
+	})
+
+	return evaluations
+}
+
+func printEvaluations(w io.Writer, output string, evaluations []evaluation) error {
+	if output == outputJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(evaluations); err != nil {
+			return fmt.Errorf("error encoding evaluations: %w", err)
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FLAG\tVALUE\tVARIANT\tREASON\tMETADATA\tERROR")
+	for _, e := range evaluations {
+		value, err := json.Marshal(e.Value)
+		if err != nil {
+			return fmt.Errorf("error encoding value of flag %s: %w", e.FlagKey, err)
+		}
+		metadata := ""
+		if len(e.Metadata) > 0 {
+			b, err := json.Marshal(e.Metadata)
+			if err != nil {
+				return fmt.Errorf("error encoding metadata of flag %s: %w", e.FlagKey, err)
+			}
+			metadata = string(b)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", e.FlagKey, value, e.Variant, e.Reason, metadata, e.Error)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("error printing evaluations: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const evalFlags = `{
+  "flags": {
+    "headerColor": {
+      "state": "ENABLED",
+      "variants": { "red": "#FF0000", "blue": "#0000FF" },
+      "defaultVariant": "red",
+      "targeting": {
+        "if": [ { "ends_with": [ { "var": "email" }, "@faas.com" ] }, "blue" ]
+      }
+    },
+    "myBoolFlag": {
+      "state": "ENABLED",
+      "variants": { "on": true, "off": false },
+      "defaultVariant": "on"
+    },
+    "disabledFlag": {
+      "state": "DISABLED",
+      "variants": { "on": true, "off": false },
+      "defaultVariant": "on"
+    }
+  }
+}`
+
+func TestEvalCmd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.json")
+	require.NoError(t, os.WriteFile(path, []byte(evalFlags), 0o600))
+	uri := "file:" + path
+	// the revision of the flag configuration is part of the evaluation metadata
+	revision := map[string]interface{}{"revision": float64(1)}
+
+	tests := map[string]struct {
+		args            []string
+		wantEvaluations []evaluation
+		wantErr         bool
+	}{
+		"all enabled flags": {
+			args: []string{"--uri", uri},
+			wantEvaluations: []evaluation{
+				{FlagKey: "headerColor", Value: "#FF0000", Variant: "red", Reason: "DEFAULT", Metadata: revision},
+				{FlagKey: "myBoolFlag", Value: true, Variant: "on", Reason: "STATIC", Metadata: revision},
+			},
+		},
+		"single flag with a context": {
+			args: []string{"--uri", uri, "--flag", "headerColor", "--context", `{"email":"user@faas.com"}`},
+			wantEvaluations: []evaluation{
+				{FlagKey: "headerColor", Value: "#0000FF", Variant: "blue", Reason: "TARGETING_MATCH", Metadata: revision},
+			},
+		},
+		"unknown flag": {
+			args: []string{"--uri", uri, "--flag", "unknown"},
+			wantEvaluations: []evaluation{
+				{FlagKey: "unknown", Reason: "ERROR", Error: "FLAG_NOT_FOUND"},
+			},
+		},
+		"invalid context": {
+			args:    []string{"--uri", uri, "--context", `{"email":`},
+			wantErr: true,
+		},
+		"unsupported output": {
+			args:    []string{"--uri", uri, "--output", "text"},
+			wantErr: true,
+		},
+		"missing source": {
+			args:    []string{"--uri", "file:" + filepath.Join(t.TempDir(), "missing.json"), "--timeout", "1s"},
+			wantErr: true,
+		},
+		"no source": {
+			args:    []string{},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			args := append([]string{"eval"}, tt.args...)
+			if !tt.wantErr {
+				args = append(args, "--output", outputJSON)
+			}
+
+			out, err := execute(t, evalCmd, args...)
+			if tt.wantErr {
+				require.Error(t, err, "the command exits with a non-zero code")
+				return
+			}
+			require.NoError(t, err)
+
+			var evaluations []evaluation
+			require.NoError(t, json.Unmarshal(out, &evaluations))
+			require.Equal(t, tt.wantEvaluations, evaluations)
+		})
+	}
+}
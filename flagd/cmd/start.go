@@ -7,6 +7,7 @@ import (
 
 	"github.com/open-feature/flagd/core/pkg/logger"
 	"github.com/open-feature/flagd/core/pkg/runtime"
+	flageval "github.com/open-feature/flagd/core/pkg/service/flag-evaluation"
 	"github.com/open-feature/flagd/core/pkg/sync"
 	syncbuilder "github.com/open-feature/flagd/core/pkg/sync/builder"
 	"github.com/spf13/cobra"
@@ -16,18 +17,23 @@ import (
 )
 
 const (
-	corsFlagName           = "cors-origin"
-	logFormatFlagName      = "log-format"
-	metricsExporter        = "metrics-exporter"
-	managementPortFlagName = "management-port"
-	otelCollectorURI       = "otel-collector-uri"
-	portFlagName           = "port"
-	serverCertPathFlagName = "server-cert-path"
-	serverKeyPathFlagName  = "server-key-path"
-	socketPathFlagName     = "socket-path"
-	sourcesFlagName        = "sources"
-	uriFlagName            = "uri"
-	docsLinkConfiguration  = "https://flagd.dev/reference/flagd-cli/flagd_start/"
+	adminTokenFlagName          = "admin-token"
+	corsFlagName                = "cors-origin"
+	eventOverflowPolicyFlagName = "event-overflow-policy"
+	eventQueueSizeFlagName      = "event-queue-size"
+	logFormatFlagName           = "log-format"
+	metricsExporter             = "metrics-exporter"
+	managementPortFlagName      = "management-port"
+	otelCollectorURI            = "otel-collector-uri"
+	portFlagName                = "port"
+	serverCertPathFlagName      = "server-cert-path"
+	serverKeyPathFlagName       = "server-key-path"
+	socketPathFlagName          = "socket-path"
+	snapshotDirFlagName         = "snapshot-dir"
+	sourcesFlagName             = "sources"
+	strictValidationFlagName    = "strict-validation"
+	uriFlagName                 = "uri"
+	docsLinkConfiguration       = "https://flagd.dev/reference/flagd-cli/flagd_start/"
 )
 
 func init() {
@@ -45,8 +51,8 @@ func init() {
 	flags.StringP(serverKeyPathFlagName, "k", "", "Server side tls key path")
 	flags.StringSliceP(
 		uriFlagName, "f", []string{}, "Set a sync provider uri to read data from, this can be a filepath,"+
-			" URL (HTTP and gRPC) or FeatureFlag custom resource. When flag keys are duplicated across multiple providers the "+
-			"merge priority follows the index of the flag arguments, as such flags from the uri at index 0 take the "+
+			" directory, URL (HTTP and gRPC), S3 object or FeatureFlag custom resource. When flag keys are duplicated "+
+			"across multiple providers the merge priority follows the index of the flag arguments, as such flags from the uri at index 0 take the "+
 			"lowest precedence, with duplicated keys being overwritten by those from the uri at index 1. "+
 			"Please note that if you are using filepath, flagd only supports files with `.yaml/.yml/.json` extension.",
 	)
@@ -62,8 +68,24 @@ func init() {
 		" be present")
 	flags.StringP(otelCollectorURI, "o", "", "Set the grpc URI of the OpenTelemetry collector "+
 		"for flagd runtime. If unset, the collector setup will be ignored and traces will not be exported.")
+	flags.Int(eventQueueSizeFlagName, flageval.DefaultEventQueueSize,
+		"Number of notifications queued for each event stream subscriber")
+	flags.String(eventOverflowPolicyFlagName, string(flageval.OverflowCoalesce),
+		"Handling of notifications for event stream subscribers whose queue is full, one of drop-oldest, coalesce "+
+			"(merges queued configuration_change events, dropping the oldest event if nothing can be merged) or disconnect")
+	flags.String(adminTokenFlagName, "", "Bearer token of the flag override API of the management port, "+
+		"the API is disabled if unset")
+	flags.String(snapshotDirFlagName, "", "Directory in which the last flag configuration applied from each source is "+
+		"persisted. On startup, flags are served from these snapshots until their source is synced, also when the "+
+		"source is unreachable. Disabled if unset.")
+	flags.Bool(strictValidationFlagName, false, "Reject flag configurations which do not conform to the flagd schema, "+
+		"reference unknown evaluators or variants, or define fractional weights not summing to 100. The last valid "+
+		"configuration of the source is kept.")
 
+	_ = viper.BindPFlag(adminTokenFlagName, flags.Lookup(adminTokenFlagName))
 	_ = viper.BindPFlag(corsFlagName, flags.Lookup(corsFlagName))
+	_ = viper.BindPFlag(eventOverflowPolicyFlagName, flags.Lookup(eventOverflowPolicyFlagName))
+	_ = viper.BindPFlag(eventQueueSizeFlagName, flags.Lookup(eventQueueSizeFlagName))
 	_ = viper.BindPFlag(logFormatFlagName, flags.Lookup(logFormatFlagName))
 	_ = viper.BindPFlag(metricsExporter, flags.Lookup(metricsExporter))
 	_ = viper.BindPFlag(managementPortFlagName, flags.Lookup(managementPortFlagName))
@@ -72,7 +94,9 @@ func init() {
 	_ = viper.BindPFlag(serverCertPathFlagName, flags.Lookup(serverCertPathFlagName))
 	_ = viper.BindPFlag(serverKeyPathFlagName, flags.Lookup(serverKeyPathFlagName))
 	_ = viper.BindPFlag(socketPathFlagName, flags.Lookup(socketPathFlagName))
+	_ = viper.BindPFlag(snapshotDirFlagName, flags.Lookup(snapshotDirFlagName))
 	_ = viper.BindPFlag(sourcesFlagName, flags.Lookup(sourcesFlagName))
+	_ = viper.BindPFlag(strictValidationFlagName, flags.Lookup(strictValidationFlagName))
 	_ = viper.BindPFlag(uriFlagName, flags.Lookup(uriFlagName))
 }
 
@@ -120,15 +144,20 @@ var startCmd = &cobra.Command{
 
 		// Build Runtime -----------------------------------------------------------
 		rt, err := runtime.FromConfig(logger, Version, runtime.Config{
-			CORS:              viper.GetStringSlice(corsFlagName),
-			MetricExporter:    viper.GetString(metricsExporter),
-			ManagementPort:    viper.GetUint16(managementPortFlagName),
-			OtelCollectorURI:  viper.GetString(otelCollectorURI),
-			ServiceCertPath:   viper.GetString(serverCertPathFlagName),
-			ServiceKeyPath:    viper.GetString(serverKeyPathFlagName),
-			ServicePort:       viper.GetUint16(portFlagName),
-			ServiceSocketPath: viper.GetString(socketPathFlagName),
-			SyncProviders:     syncProviders,
+			AdminToken:          viper.GetString(adminTokenFlagName),
+			CORS:                viper.GetStringSlice(corsFlagName),
+			EventOverflowPolicy: viper.GetString(eventOverflowPolicyFlagName),
+			EventQueueSize:      viper.GetInt(eventQueueSizeFlagName),
+			MetricExporter:      viper.GetString(metricsExporter),
+			ManagementPort:      viper.GetUint16(managementPortFlagName),
+			OtelCollectorURI:    viper.GetString(otelCollectorURI),
+			ServiceCertPath:     viper.GetString(serverCertPathFlagName),
+			ServiceKeyPath:      viper.GetString(serverKeyPathFlagName),
+			ServicePort:         viper.GetUint16(portFlagName),
+			ServiceSocketPath:   viper.GetString(socketPathFlagName),
+			SnapshotDir:         viper.GetString(snapshotDirFlagName),
+			StrictValidation:    viper.GetBool(strictValidationFlagName),
+			SyncProviders:       syncProviders,
 		})
 		if err != nil {
 			rtLogger.Fatal(err.Error())
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/open-feature/flagd/core/pkg/evaluator"
+	"github.com/open-feature/flagd/core/pkg/logger"
+	"github.com/open-feature/flagd/core/pkg/store"
+	"github.com/open-feature/flagd/core/pkg/sync/file"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputFlagName = "output"
+	outputText     = "text"
+	outputJSON     = "json"
+)
+
+// fileIssue is a validation issue located within a flag definition file. Line and column are 0 if the issue can not be
+// attributed to a specific location.
+type fileIssue struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate <file>...",
+	Short: "Validate flag definition files",
+	Long: "Validate flag definition files (.json, .yaml or .yml) against the flagd schema and check their default " +
+		"variants and targeting rules. Issues are reported along with their location, and the command exits with a " +
+		"non-zero code if any issue is found.",
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, err := cmd.Flags().GetString(outputFlagName)
+		if err != nil {
+			return fmt.Errorf("error reading output flag: %w", err)
+		}
+		if output != outputText && output != outputJSON {
+			return fmt.Errorf("unsupported output format '%s', use %s or %s", output, outputText, outputJSON)
+		}
+
+		// the evaluator logs schema violations, which are reported as issues instead
+		je := evaluator.NewJSON(logger.NewLogger(zap.NewNop(), false), store.NewFlags())
+
+		issues := []fileIssue{}
+		for _, path := range args {
+			issues = append(issues, validateFile(cmd, je, path)...)
+		}
+
+		if err := printIssues(cmd.OutOrStdout(), output, issues); err != nil {
+			return err
+		}
+
+		if len(issues) > 0 {
+			return fmt.Errorf("found %d issue(s) in flag definition files", len(issues))
+		}
+		return nil
+	},
+}
+
+func init() {
+	validateCmd.Flags().StringP(outputFlagName, "o", outputText, "Output format, either text or json")
+}
+
+// validateFile loads the file the same way the file sync provider does and returns the issues found in it
+func validateFile(cmd *cobra.Command, je *evaluator.JSON, path string) []fileIssue {
+	rawFile, err := os.ReadFile(path)
+	if err != nil {
+		return []fileIssue{{File: path, Message: fmt.Sprintf("error reading file: %s", err)}}
+	}
+
+	// JSON is a subset of YAML, hence the node tree is used to locate issues in both formats
+	var root yaml.Node
+	if err := yaml.Unmarshal(rawFile, &root); err != nil {
+		return []fileIssue{{File: path, Message: fmt.Sprintf("error parsing file: %s", err)}}
+	}
+
+	config, err := file.NewFileSync(path, logger.NewLogger(zap.NewNop(), false)).Fetch(cmd.Context())
+	if err != nil {
+		return []fileIssue{{File: path, Message: err.Error()}}
+	}
+
+	issues := je.Validate(config)
+	fileIssues := make([]fileIssue, 0, len(issues))
+	for _, issue := range issues {
+		fi := fileIssue{File: path, Path: issue.Path, Message: issue.Message}
+		if node := locate(&root, issue.Path); node != nil {
+			fi.Line = node.Line
+			fi.Column = node.Column
+		}
+		fileIssues = append(fileIssues, fi)
+	}
+
+	return fileIssues
+}
+
+// locate returns the node closest to the given dot separated path. Flag keys may contain dots, so the longest key
+// matching the start of the remaining path is picked.
+func locate(root *yaml.Node, path string) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if path == "" {
+		return node
+	}
+
+	segments := strings.Split(path, ".")
+	for len(segments) > 0 {
+		key, value, consumed := lookupChild(node, segments)
+		if value == nil {
+			break
+		}
+		segments = segments[consumed:]
+
+		// collections start on the line following their key, hence the key is the more useful location
+		if len(segments) == 0 && key != nil && (value.Kind == yaml.MappingNode || value.Kind == yaml.SequenceNode) {
+			return key
+		}
+		node = value
+	}
+
+	return node
+}
+
+// lookupChild returns the key and value nodes of the child matching the start of the segments, along with the number
+// of segments it consumed. The key node is nil for elements of a sequence.
+func lookupChild(node *yaml.Node, segments []string) (*yaml.Node, *yaml.Node, int) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for consumed := len(segments); consumed > 0; consumed-- {
+			key := strings.Join(segments[:consumed], ".")
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					return node.Content[i], node.Content[i+1], consumed
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(segments[0])
+		if err == nil && index >= 0 && index < len(node.Content) {
+			return nil, node.Content[index], 1
+		}
+	}
+
+	return nil, nil, 0
+}
+
+func printIssues(w io.Writer, output string, issues []fileIssue) error {
+	if output == outputJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(issues); err != nil {
+			return fmt.Errorf("error encoding issues: %w", err)
+		}
+		return nil
+	}
+
+	for _, issue := range issues {
+		location := issue.File
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", issue.File, issue.Line, issue.Column)
+		}
+		message := issue.Message
+		if issue.Path != "" {
+			message = fmt.Sprintf("%s: %s", issue.Path, issue.Message)
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s\n", location, message); err != nil {
+			return fmt.Errorf("error printing issues: %w", err)
+		}
+	}
+
+	return nil
+}
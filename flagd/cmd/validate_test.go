@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const validYAMLFlags = `flags:
+  myBoolFlag:
+    state: ENABLED
+    variants:
+      "on": true
+      "off": false
+    defaultVariant: "on"
+`
+
+func TestValidateCmd(t *testing.T) {
+	tests := map[string]struct {
+		fileName string
+		content  string
+		output   string
+		// issues are compared without their message, which is only expected to be set
+		wantIssues []fileIssue
+		wantErr    bool
+	}{
+		"valid JSON file": {
+			fileName: "flags.json",
+			content: `{
+  "flags": {
+    "myBoolFlag": { "state": "ENABLED", "variants": { "on": true, "off": false }, "defaultVariant": "on" }
+  }
+}`,
+			output:     outputJSON,
+			wantIssues: []fileIssue{},
+		},
+		"valid YAML file": {
+			fileName:   "flags.yaml",
+			content:    validYAMLFlags,
+			output:     outputJSON,
+			wantIssues: []fileIssue{},
+		},
+		"invalid default variant": {
+			fileName: "flags.yaml",
+			content: `flags:
+  myBoolFlag:
+    state: ENABLED
+    variants:
+      "on": true
+      "off": false
+    defaultVariant: maybe
+`,
+			output:     outputJSON,
+			wantIssues: []fileIssue{{Line: 7, Column: 21, Path: "flags.myBoolFlag.defaultVariant"}},
+			wantErr:    true,
+		},
+		"invalid targeting": {
+			fileName: "flags.json",
+			content: `{
+  "flags": {
+    "myBoolFlag": {
+      "state": "ENABLED",
+      "variants": { "on": true, "off": false },
+      "defaultVariant": "on",
+      "targeting": {
+        "if": [ { "==": [ 1, 1 ] }, "maybe", "off" ]
+      }
+    }
+  }
+}`,
+			output:     outputJSON,
+			wantIssues: []fileIssue{{Line: 7, Column: 7, Path: "flags.myBoolFlag.targeting"}},
+			wantErr:    true,
+		},
+		"unparsable file": {
+			fileName:   "flags.json",
+			content:    `{"flags":`,
+			output:     outputJSON,
+			wantIssues: []fileIssue{{}},
+			wantErr:    true,
+		},
+		"unsupported output": {
+			fileName: "flags.yaml",
+			content:  validYAMLFlags,
+			output:   "xml",
+			wantErr:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.fileName)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			out, err := execute(t, validateCmd, "validate", "--output", tt.output, path)
+			if tt.wantErr {
+				require.Error(t, err, "the command exits with a non-zero code")
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantIssues == nil {
+				return
+			}
+
+			var issues []fileIssue
+			require.NoError(t, json.Unmarshal(out, &issues))
+			for i := range issues {
+				require.Equal(t, path, issues[i].File)
+				require.NotEmpty(t, issues[i].Message)
+				issues[i].File = ""
+				issues[i].Message = ""
+			}
+			require.Equal(t, tt.wantIssues, issues)
+		})
+	}
+}
+
+func TestValidateCmd_missingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+
+	out, err := execute(t, validateCmd, "validate", path)
+	require.Error(t, err)
+	require.Contains(t, string(out), path+": error reading file")
+}
+
+func TestLocate(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`flags:
+  my.flag:
+    state: ENABLED
+    targeting:
+      if:
+        - ends_with:
+            - var: email
+            - "@faas.com"
+        - "on"
+        - "off"
+`), &root))
+
+	tests := map[string]struct {
+		path       string
+		wantLine   int
+		wantColumn int
+	}{
+		"root":                          {path: "", wantLine: 1, wantColumn: 1},
+		"flag keys containing dots":     {path: "flags.my.flag.state", wantLine: 3, wantColumn: 12},
+		"collections are their key":     {path: "flags.my.flag.targeting", wantLine: 4, wantColumn: 5},
+		"sequence elements":             {path: "flags.my.flag.targeting.if.1", wantLine: 9, wantColumn: 11},
+		"unknown paths are the closest": {path: "flags.my.flag.targeting.unknown", wantLine: 5, wantColumn: 7},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			node := locate(&root, tt.path)
+			require.NotNil(t, node)
+			require.Equal(t, tt.wantLine, node.Line)
+			require.Equal(t, tt.wantColumn, node.Column)
+		})
+	}
+}
+
+func TestLookupChild(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`{"a": 1, "a.b": 2, "list": [3, 4]}`), &root))
+	mapping := root.Content[0]
+	list := mapping.Content[5]
+
+	tests := map[string]struct {
+		node         *yaml.Node
+		segments     []string
+		wantKey      string
+		wantValue    string
+		wantConsumed int
+	}{
+		"longest matching key": {
+			node: mapping, segments: []string{"a", "b", "c"}, wantKey: "a.b", wantValue: "2", wantConsumed: 2,
+		},
+		"single key":            {node: mapping, segments: []string{"a", "c"}, wantKey: "a", wantValue: "1", wantConsumed: 1},
+		"unknown key":           {node: mapping, segments: []string{"b"}},
+		"sequence element":      {node: list, segments: []string{"1", "c"}, wantValue: "4", wantConsumed: 1},
+		"out of range element":  {node: list, segments: []string{"2"}},
+		"invalid element index": {node: list, segments: []string{"a"}},
+		"scalar":                {node: list.Content[0], segments: []string{"a"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			key, value, consumed := lookupChild(tt.node, tt.segments)
+			require.Equal(t, tt.wantConsumed, consumed)
+
+			if tt.wantKey == "" {
+				require.Nil(t, key)
+			} else {
+				require.Equal(t, tt.wantKey, key.Value)
+			}
+			if tt.wantValue == "" {
+				require.Nil(t, value)
+			} else {
+				require.Equal(t, tt.wantValue, value.Value)
+			}
+		})
+	}
+}
+
+func TestPrintIssues(t *testing.T) {
+	issues := []fileIssue{
+		{File: "flags.yaml", Line: 7, Column: 21, Path: "flags.myFlag.defaultVariant", Message: "invalid variant"},
+		{File: "flags.json", Message: "error parsing file"},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, printIssues(&out, outputText, issues))
+	require.Equal(t, "flags.yaml:7:21: flags.myFlag.defaultVariant: invalid variant\n"+
+		"flags.json: error parsing file\n", out.String())
+}
+
+// execute runs the given subcommand of the root command with the given arguments, and returns its output. The flags of
+// the subcommand are reset first, as cobra keeps their values between executions.
+func execute(t *testing.T, cmd *cobra.Command, args ...string) ([]byte, error) {
+	t.Helper()
+
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if v, ok := f.Value.(pflag.SliceValue); ok {
+			require.NoError(t, v.Replace([]string{}))
+		} else {
+			require.NoError(t, f.Value.Set(f.DefValue))
+		}
+		f.Changed = false
+	})
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(io.Discard)
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+
+	return out.Bytes(), err
+}